@@ -0,0 +1,213 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/KimMaru10/bl-cli/internal/api"
+	"github.com/KimMaru10/bl-cli/internal/cmdutil"
+	"github.com/spf13/cobra"
+)
+
+// NewAPICmd returns the api command, which calls arbitrary Backlog API
+// endpoints with the current space's credentials.
+func NewAPICmd() *cobra.Command {
+	var (
+		method      string
+		fields      []string
+		input       string
+		contentType string
+		paginate    bool
+		exporter    *cmdutil.Exporter
+	)
+
+	cmd := &cobra.Command{
+		Use:   "api <path>",
+		Short: "Backlog API を直接呼び出す",
+		Long: `現在のスペースの認証情報で Backlog API を呼び出し、レスポンスを整形して表示します。
+<path> は /api/v2 からの相対パスです（例: /wikis, projects/PROJ/git/repositories）。
+
+-f で指定したフィールドは、GET ではクエリ文字列、それ以外ではフォームとして送信されます。
+配列パラメータは projectId[]=1 -f projectId[]=2 のように繰り返して指定します。
+--input で送るボディの Content-Type は -H で変更できます（既定はフォーム）。`,
+		Example: `  bl api /users/myself
+  bl api /wikis -f projectIdOrKey=PROJ
+  bl api /issues -f projectId[]=1 -f count=100 --paginate
+  bl api -X POST /issues/PROJ-1/stars
+  bl api -X PATCH /issues/PROJ-1 --input body.txt
+  bl api -X POST /projects/PROJ/webhooks --input hook.json -H application/json`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			_, client, err := cmdutil.LoadConfigAndClient()
+			if err != nil {
+				return err
+			}
+
+			path, params, err := parsePath(args[0])
+			if err != nil {
+				return err
+			}
+			for _, f := range fields {
+				k, v, ok := strings.Cut(f, "=")
+				if !ok {
+					return fmt.Errorf("フィールドは key=value 形式で指定してください: %s", f)
+				}
+				params.Add(k, v)
+			}
+
+			var body []byte
+			if input != "" {
+				body, err = readInput(input)
+				if err != nil {
+					return err
+				}
+			}
+
+			if method == "" {
+				method = http.MethodGet
+				// --paginate implies GET even with fields.
+				if !paginate && (len(fields) > 0 || body != nil) {
+					method = http.MethodPost
+				}
+			}
+			method = strings.ToUpper(method)
+
+			var data []byte
+			if paginate {
+				if method != http.MethodGet {
+					return fmt.Errorf("--paginate は GET リクエストでのみ使用できます")
+				}
+				data, err = getAllPages(ctx, client, path, params)
+			} else {
+				data, err = client.Raw(ctx, method, path, params, body, bodyContentType(body, contentType))
+			}
+			if err != nil {
+				return err
+			}
+
+			return writeResponse(exporter, data)
+		},
+	}
+
+	cmd.Flags().StringVarP(&method, "method", "X", "", "HTTP メソッド（既定: GET、フィールドや --input がある場合は POST）")
+	cmd.Flags().StringArrayVarP(&fields, "field", "f", nil, "リクエストパラメータ（key=value、繰り返し指定可）")
+	cmd.Flags().StringVar(&input, "input", "", "リクエストボディとして送信するファイル（- で標準入力）")
+	cmd.Flags().StringVarP(&contentType, "content-type", "H", "", "--input のボディの Content-Type（既定: application/x-www-form-urlencoded）")
+	cmd.Flags().BoolVar(&paginate, "paginate", false, "offset を進めて全ページを取得し、1 つの配列にまとめる")
+	exporter = cmdutil.AddFormatFlags(cmd)
+
+	cmd.AddCommand(newRateLimitCmd())
+
+	return cmd
+}
+
+// parsePath normalizes a user-supplied path to one relative to /api/v2
+// and splits off any query string.
+func parsePath(raw string) (string, url.Values, error) {
+	p, q, _ := strings.Cut(raw, "?")
+	params, err := url.ParseQuery(q)
+	if err != nil {
+		return "", nil, fmt.Errorf("クエリ文字列の解析に失敗しました: %w", err)
+	}
+	p = "/" + strings.TrimPrefix(p, "/")
+	p = strings.TrimPrefix(p, "/api/v2")
+	if p == "" {
+		p = "/"
+	}
+	return p, params, nil
+}
+
+// bodyContentType returns the Content-Type for a request body. Without a
+// body it is left to the client, which encodes the fields as a form. A
+// header-style value such as "Content-Type: application/json" is accepted.
+func bodyContentType(body []byte, contentType string) string {
+	if body == nil {
+		return ""
+	}
+	if name, value, ok := strings.Cut(contentType, ":"); ok && strings.EqualFold(strings.TrimSpace(name), "Content-Type") {
+		contentType = value
+	}
+	if contentType = strings.TrimSpace(contentType); contentType != "" {
+		return contentType
+	}
+	return "application/x-www-form-urlencoded"
+}
+
+func readInput(name string) ([]byte, error) {
+	if name == "-" {
+		data, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return nil, fmt.Errorf("標準入力の読み込みに失敗しました: %w", err)
+		}
+		return data, nil
+	}
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return nil, fmt.Errorf("ファイルの読み込みに失敗しました: %w", err)
+	}
+	return data, nil
+}
+
+// pageSize is the count requested per page when --paginate is given
+// without an explicit count. It is also the largest page Backlog returns,
+// so larger counts are lowered to it.
+const pageSize = 100
+
+// getAllPages walks offset until a short page is returned and merges the
+// resulting arrays. Only offset/count style endpoints are supported.
+func getAllPages(ctx context.Context, client *api.Client, path string, params url.Values) ([]byte, error) {
+	count, err := strconv.Atoi(params.Get("count"))
+	if err != nil || count <= 0 || count > pageSize {
+		count = pageSize
+	}
+	params.Set("count", strconv.Itoa(count))
+	offset, _ := strconv.Atoi(params.Get("offset"))
+
+	all := []json.RawMessage{}
+	for {
+		params.Set("offset", strconv.Itoa(offset))
+		data, err := client.Raw(ctx, http.MethodGet, path, params, nil, "")
+		if err != nil {
+			return nil, err
+		}
+		var page []json.RawMessage
+		if err := json.Unmarshal(data, &page); err != nil {
+			return nil, fmt.Errorf("--paginate は配列を返すエンドポイントでのみ使用できます")
+		}
+		all = append(all, page...)
+		if len(page) < count {
+			break
+		}
+		offset += len(page)
+	}
+	return json.Marshal(all)
+}
+
+// writeResponse pretty-prints JSON responses, keeping the field order of
+// the API, and writes anything else as-is.
+func writeResponse(exporter *cmdutil.Exporter, data []byte) error {
+	if !json.Valid(data) {
+		_, err := os.Stdout.Write(data)
+		return err
+	}
+	if exporter.Enabled() {
+		return exporter.Write(os.Stdout, json.RawMessage(data))
+	}
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, data, "", "  "); err != nil {
+		return fmt.Errorf("JSON の整形に失敗しました: %w", err)
+	}
+	buf.WriteByte('\n')
+	_, err := buf.WriteTo(os.Stdout)
+	return err
+}
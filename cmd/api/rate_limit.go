@@ -0,0 +1,75 @@
+package api
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/KimMaru10/bl-cli/internal/api"
+	"github.com/KimMaru10/bl-cli/internal/cmdutil"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/cobra"
+)
+
+var (
+	headerStyle = lipgloss.NewStyle().Bold(true)
+	warnStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("1"))
+)
+
+func newRateLimitCmd() *cobra.Command {
+	var exporter *cmdutil.Exporter
+
+	cmd := &cobra.Command{
+		Use:   "rate-limit",
+		Short: "API のレート制限の残量を表示する",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			_, client, err := cmdutil.LoadConfigAndClient()
+			if err != nil {
+				return err
+			}
+
+			status, err := client.GetRateLimit(ctx)
+			if err != nil {
+				return err
+			}
+
+			if exporter.Enabled() {
+				return exporter.Write(os.Stdout, status)
+			}
+
+			fmt.Printf("%s\t%s\t%s\t%s\n",
+				headerStyle.Render("TYPE"),
+				headerStyle.Render("REMAINING"),
+				headerStyle.Render("LIMIT"),
+				headerStyle.Render("RESET"),
+			)
+			for _, row := range []struct {
+				name string
+				rl   api.RateLimit
+			}{
+				{"read", status.Read},
+				{"update", status.Update},
+				{"search", status.Search},
+				{"icon", status.Icon},
+			} {
+				remaining := fmt.Sprint(row.rl.Remaining)
+				if row.rl.Remaining == 0 {
+					remaining = warnStyle.Render(remaining)
+				}
+				fmt.Printf("%s\t%s\t%d\t%s\n",
+					row.name,
+					remaining,
+					row.rl.Limit,
+					row.rl.ResetTime().Format(time.DateTime),
+				)
+			}
+			return nil
+		},
+	}
+
+	exporter = cmdutil.AddJSONFlags(cmd, cmdutil.StructFields(api.RateLimitStatus{}))
+
+	return cmd
+}
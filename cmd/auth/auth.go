@@ -13,6 +13,7 @@ func NewAuthCmd() *cobra.Command {
 	cmd.AddCommand(newLogoutCmd())
 	cmd.AddCommand(newStatusCmd())
 	cmd.AddCommand(newSwitchCmd())
+	cmd.AddCommand(newMigrateCmd())
 
 	return cmd
 }
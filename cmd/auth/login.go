@@ -1,16 +1,27 @@
 package auth
 
 import (
+	"context"
 	"fmt"
+	"os"
+	"slices"
 	"strings"
 
-	"github.com/KimMaru10/bl-cli/internal/api"
+	"github.com/KimMaru10/bl-cli/internal/browser"
+	"github.com/KimMaru10/bl-cli/internal/cmdutil"
 	"github.com/KimMaru10/bl-cli/internal/config"
+	"github.com/KimMaru10/bl-cli/internal/oauth"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/spf13/cobra"
 )
 
+// Environment variables used when --client-id and --client-secret are omitted.
+const (
+	envOAuthClientID     = "BL_OAUTH_CLIENT_ID"
+	envOAuthClientSecret = "BL_OAUTH_CLIENT_SECRET"
+)
+
 type loginStep int
 
 const (
@@ -22,12 +33,13 @@ const (
 
 type loginModel struct {
 	step     loginStep
+	oauth    bool
 	inputs   []textinput.Model
 	err      error
 	quitting bool
 }
 
-func newLoginModel(suggestedAlias string) loginModel {
+func newLoginModel(suggestedAlias string, oauth bool) loginModel {
 	spaceInput := textinput.New()
 	spaceInput.Placeholder = "https://myteam.backlog.com"
 	spaceInput.Focus()
@@ -47,6 +59,7 @@ func newLoginModel(suggestedAlias string) loginModel {
 
 	return loginModel{
 		step:   stepSpaceURL,
+		oauth:  oauth,
 		inputs: []textinput.Model{spaceInput, apiKeyInput, aliasInput},
 	}
 }
@@ -65,20 +78,17 @@ func (m loginModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case tea.KeyEnter:
 			switch m.step {
 			case stepSpaceURL:
-				m.step = stepAPIKey
 				m.inputs[0].Blur()
+				// OAuth logins get their token from the browser instead
+				if m.oauth {
+					return m.toAlias()
+				}
+				m.step = stepAPIKey
 				m.inputs[1].Focus()
 				return m, textinput.Blink
 			case stepAPIKey:
-				// Derive suggested alias from URL for placeholder
-				spaceURL := strings.TrimRight(strings.TrimSpace(m.inputs[0].Value()), "/")
-				suggested := config.ExtractAlias(spaceURL)
-				m.inputs[2].Placeholder = suggested
-
-				m.step = stepAlias
 				m.inputs[1].Blur()
-				m.inputs[2].Focus()
-				return m, textinput.Blink
+				return m.toAlias()
 			case stepAlias:
 				m.step = stepDone
 				return m, tea.Quit
@@ -91,6 +101,16 @@ func (m loginModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, cmd
 }
 
+func (m loginModel) toAlias() (tea.Model, tea.Cmd) {
+	// Derive suggested alias from URL for placeholder
+	spaceURL := strings.TrimRight(strings.TrimSpace(m.inputs[0].Value()), "/")
+	m.inputs[2].Placeholder = config.ExtractAlias(spaceURL)
+
+	m.step = stepAlias
+	m.inputs[2].Focus()
+	return m, textinput.Blink
+}
+
 func (m loginModel) View() string {
 	var b strings.Builder
 
@@ -98,7 +118,7 @@ func (m loginModel) View() string {
 		b.WriteString(m.inputs[0].View())
 		b.WriteString("\n")
 	}
-	if m.step >= stepAPIKey {
+	if m.step >= stepAPIKey && !m.oauth {
 		b.WriteString(m.inputs[1].View())
 		b.WriteString("\n")
 	}
@@ -111,11 +131,38 @@ func (m loginModel) View() string {
 }
 
 func newLoginCmd() *cobra.Command {
-	return &cobra.Command{
+	var (
+		store        string
+		helper       string
+		useOAuth     bool
+		clientID     string
+		clientSecret string
+		redirectPort int
+	)
+
+	cmd := &cobra.Command{
 		Use:   "login",
 		Short: "Backlog スペースを追加認証する",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			m := newLoginModel("myteam")
+			ctx := cmd.Context()
+
+			if !slices.Contains(config.StoreNames, store) {
+				return fmt.Errorf("--store には %s のいずれかを指定してください", strings.Join(config.StoreNames, ", "))
+			}
+
+			if useOAuth {
+				if clientID == "" {
+					clientID = os.Getenv(envOAuthClientID)
+				}
+				if clientSecret == "" {
+					clientSecret = os.Getenv(envOAuthClientSecret)
+				}
+				if clientID == "" || clientSecret == "" {
+					return fmt.Errorf("--oauth には --client-id と --client-secret（または %s と %s）が必要です", envOAuthClientID, envOAuthClientSecret)
+				}
+			}
+
+			m := newLoginModel("myteam", useOAuth)
 			p := tea.NewProgram(m)
 			finalModel, err := p.Run()
 			if err != nil {
@@ -131,7 +178,11 @@ func newLoginCmd() *cobra.Command {
 			apiKey := strings.TrimSpace(result.inputs[1].Value())
 			alias := strings.TrimSpace(result.inputs[2].Value())
 
-			if spaceURL == "" || apiKey == "" {
+			if spaceURL == "" {
+				fmt.Println(errorStyle.Render("✗ スペースURLは必須です"))
+				return nil
+			}
+			if apiKey == "" && !useOAuth {
 				fmt.Println(errorStyle.Render("✗ スペースURLとAPIキーは必須です"))
 				return nil
 			}
@@ -141,25 +192,60 @@ func newLoginCmd() *cobra.Command {
 				alias = config.ExtractAlias(spaceURL)
 			}
 
+			var creds *config.OAuthCredentials
+			if useOAuth {
+				creds, err = oauthLogin(ctx, spaceURL, clientID, clientSecret, redirectPort)
+				if err != nil {
+					fmt.Println(errorStyle.Render("✗ OAuth 認証に失敗しました: " + err.Error()))
+					return nil
+				}
+			}
+
 			// Validate credentials
-			client := api.NewClient(spaceURL, apiKey)
-			user, err := client.GetMyself()
+			client := cmdutil.NewClient(spaceURL, apiKey)
+			if creds != nil {
+				client = cmdutil.NewSpaceClient(alias, &config.SpaceConfig{
+					SpaceURL: spaceURL,
+					AuthType: config.AuthOAuth,
+					OAuth:    creds,
+				})
+			}
+			user, err := client.GetMyself(ctx)
 			if err != nil {
 				fmt.Println(errorStyle.Render("✗ 認証に失敗しました: " + err.Error()))
 				return nil
 			}
 
 			// Load existing config and add/update space
-			cfg, _ := config.Load()
+			cfg, err := config.LoadGlobal()
+			if err != nil {
+				return fmt.Errorf("設定の読み込みに失敗しました: %w", err)
+			}
 			if cfg.Spaces == nil {
 				cfg.Spaces = make(map[string]config.SpaceConfig)
 			}
 
-			cfg.Spaces[alias] = config.SpaceConfig{
+			prev, exists := cfg.Spaces[alias]
+			space := config.SpaceConfig{
 				SpaceURL:       spaceURL,
-				APIKey:         apiKey,
-				DefaultProject: cfg.Spaces[alias].DefaultProject, // preserve existing default_project
+				DefaultProject: prev.DefaultProject, // preserve existing default_project
+				Projects:       prev.Projects,
+			}
+			if creds != nil {
+				err = space.StoreOAuth(alias, store, helper, creds)
+			} else {
+				err = space.StoreAPIKey(alias, store, helper, apiKey)
+			}
+			if err != nil {
+				return err
+			}
+			// Drop the old key if it was kept in a different store
+			if exists && (prev.CredentialStore != space.CredentialStore || prev.CredentialHelper != space.CredentialHelper) {
+				if err := prev.DeleteCredentials(alias); err != nil {
+					fmt.Println(infoStyle.Render("  以前の認証情報の削除に失敗しました: " + err.Error()))
+				}
 			}
+			cfg.Spaces[alias] = space
 
 			// Set as current if it's the first space or no current is set
 			if cfg.CurrentSpace == "" || len(cfg.Spaces) == 1 {
@@ -177,4 +263,37 @@ func newLoginCmd() *cobra.Command {
 			return nil
 		},
 	}
+
+	cmd.Flags().StringVar(&store, "store", config.StorePlain, "認証情報の保存先（"+strings.Join(config.StoreNames, ", ")+"）")
+	cmd.Flags().StringVar(&helper, "helper", "", "--store helper で使用する credential helper のコマンド")
+	cmd.Flags().BoolVar(&useOAuth, "oauth", false, "API キーの代わりに OAuth 2.0 で認証する")
+	cmd.Flags().StringVar(&clientID, "client-id", "", "OAuth アプリケーションの Client ID（環境変数 "+envOAuthClientID+"）")
+	cmd.Flags().StringVar(&clientSecret, "client-secret", "", "OAuth アプリケーションの Client Secret（環境変数 "+envOAuthClientSecret+"）")
+	cmd.Flags().IntVar(&redirectPort, "redirect-port", oauth.DefaultRedirectPort, "OAuth のリダイレクトを受け取るローカルポート")
+
+	return cmd
+}
+
+// oauthLogin runs the browser-based authorization code flow and returns
+// the resulting credentials.
+func oauthLogin(ctx context.Context, spaceURL, clientID, clientSecret string, port int) (*config.OAuthCredentials, error) {
+	conf := oauth.Config(spaceURL, clientID, clientSecret, port)
+	tok, err := oauth.Login(ctx, conf, port, func(authURL string) error {
+		fmt.Println(infoStyle.Render("ブラウザで認可ページを開きます。開かない場合は次の URL にアクセスしてください:"))
+		fmt.Println("  " + authURL)
+		if err := browser.Open(authURL); err != nil {
+			fmt.Println(infoStyle.Render("  ブラウザを起動できませんでした: " + err.Error()))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &config.OAuthCredentials{
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		AccessToken:  tok.AccessToken,
+		RefreshToken: tok.RefreshToken,
+		Expiry:       tok.Expiry,
+	}, nil
 }
@@ -82,6 +82,11 @@ func newLogoutCmd() *cobra.Command {
 		Short: "認証情報を削除する",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if all {
+				if cfg, err := config.LoadGlobal(); err == nil {
+					for name, space := range cfg.Spaces {
+						deleteCredentials(name, space)
+					}
+				}
 				if err := config.Delete(); err != nil {
 					return fmt.Errorf("認証情報の削除に失敗しました: %w", err)
 				}
@@ -89,7 +94,7 @@ func newLogoutCmd() *cobra.Command {
 				return nil
 			}
 
-			cfg, err := config.Load()
+			cfg, err := config.LoadGlobal()
 			if err != nil {
 				return fmt.Errorf("設定の読み込みに失敗しました: %w", err)
 			}
@@ -101,6 +106,9 @@ func newLogoutCmd() *cobra.Command {
 
 			// If only one space, delete it directly
 			if len(cfg.Spaces) == 1 {
+				for name, space := range cfg.Spaces {
+					deleteCredentials(name, space)
+				}
 				if err := config.Delete(); err != nil {
 					return fmt.Errorf("認証情報の削除に失敗しました: %w", err)
 				}
@@ -133,6 +141,7 @@ func newLogoutCmd() *cobra.Command {
 				return nil
 			}
 
+			deleteCredentials(result.selected, cfg.Spaces[result.selected])
 			delete(cfg.Spaces, result.selected)
 
 			// If we deleted the current space, switch to another
@@ -162,3 +171,11 @@ func newLogoutCmd() *cobra.Command {
 	cmd.Flags().BoolVar(&all, "all", false, "すべてのスペースの認証情報を削除する")
 	return cmd
 }
+
+// deleteCredentials removes a space's credentials from its secret store. Failures are
+// reported but do not stop the logout.
+func deleteCredentials(alias string, space config.SpaceConfig) {
+	if err := space.DeleteCredentials(alias); err != nil {
+		fmt.Println(errorStyle.Render("✗ " + alias + ": " + err.Error()))
+	}
+}
@@ -0,0 +1,83 @@
+package auth
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/KimMaru10/bl-cli/internal/config"
+	"github.com/spf13/cobra"
+)
+
+func newMigrateCmd() *cobra.Command {
+	var (
+		store  string
+		helper string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "保存済みの認証情報を別の保存先に移行する",
+		Long: `登録済みスペースの API キー（または OAuth トークン）を --store で指定した保存先に移します。
+config.yaml に平文で保存されている API キーをキーリングなどへ移す際に使用します。`,
+		Example: `  bl auth migrate --store keyring
+  bl auth migrate --store helper --helper pass
+  bl auth migrate --store file`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !slices.Contains(config.StoreNames, store) {
+				return fmt.Errorf("--store には %s のいずれかを指定してください", strings.Join(config.StoreNames, ", "))
+			}
+
+			cfg, err := config.LoadGlobal()
+			if err != nil {
+				return fmt.Errorf("設定の読み込みに失敗しました: %w", err)
+			}
+
+			if len(cfg.Spaces) == 0 {
+				fmt.Println(infoStyle.Render("登録されたスペースがありません"))
+				return nil
+			}
+
+			for _, name := range cfg.SpaceNames() {
+				prev := cfg.Spaces[name]
+				current := prev.CredentialStore
+				if current == "" {
+					current = config.StorePlain
+				}
+				if current == store && prev.CredentialHelper == helper {
+					fmt.Println(infoStyle.Render("  " + name + ": 移行済みです"))
+					continue
+				}
+
+				if err := prev.LoadCredentials(name); err != nil {
+					return err
+				}
+
+				space := prev
+				if prev.AuthType == config.AuthOAuth {
+					err = space.StoreOAuth(name, store, helper, prev.OAuth)
+				} else {
+					err = space.StoreAPIKey(name, store, helper, prev.APIKey)
+				}
+				if err != nil {
+					return err
+				}
+				cfg.Spaces[name] = space
+
+				// Save before removing the old copy so the key is never lost
+				if err := config.Save(cfg); err != nil {
+					return fmt.Errorf("設定の保存に失敗しました: %w", err)
+				}
+				deleteCredentials(name, prev)
+
+				fmt.Println(successStyle.Render(fmt.Sprintf("✔ %s: %s → %s", name, current, store)))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&store, "store", config.StoreKeyring, "移行先（"+strings.Join(config.StoreNames, ", ")+"）")
+	cmd.Flags().StringVar(&helper, "helper", "", "--store helper で使用する credential helper のコマンド")
+
+	return cmd
+}
@@ -0,0 +1,116 @@
+package auth
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/KimMaru10/bl-cli/internal/config"
+)
+
+// fakeHelper keeps each secret in a file named after the space.
+const fakeHelper = `#!/bin/sh
+dir=$(dirname "$0")
+while IFS='=' read -r k v; do
+	case $k in
+	space) space=$v ;;
+	secret) secret=$v ;;
+	esac
+done
+case $1 in
+get) printf 'secret=%s\n' "$(cat "$dir/$space.secret")" ;;
+store) printf '%s' "$secret" > "$dir/$space.secret" ;;
+erase) rm -f "$dir/$space.secret" ;;
+esac
+`
+
+func runMigrate(t *testing.T, args ...string) {
+	t.Helper()
+	cmd := newMigrateCmd()
+	cmd.SetArgs(args)
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("migrate %v error = %v", args, err)
+	}
+}
+
+func TestMigrate(t *testing.T) {
+	t.Setenv(config.EnvConfigDir, t.TempDir())
+	helperDir := t.TempDir()
+	helper := filepath.Join(helperDir, "bl-credential-fake")
+	if err := os.WriteFile(helper, []byte(fakeHelper), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	oauth := &config.OAuthCredentials{
+		ClientID:     "client",
+		AccessToken:  "access",
+		RefreshToken: "refresh",
+		Expiry:       time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC),
+	}
+	err := config.Save(&config.Config{
+		CurrentSpace: "main",
+		Spaces: map[string]config.SpaceConfig{
+			"main":  {SpaceURL: "https://main.backlog.com", APIKey: "main-key"},
+			"oauth": {SpaceURL: "https://oauth.backlog.com", AuthType: config.AuthOAuth, OAuth: oauth},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	runMigrate(t, "--store", config.StoreHelper, "--helper", helper)
+
+	cfg, err := config.LoadGlobal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, space := range cfg.Spaces {
+		if space.CredentialStore != config.StoreHelper || space.CredentialHelper != helper {
+			t.Errorf("%s: store = %q %q, want helper %q", name, space.CredentialStore, space.CredentialHelper, helper)
+		}
+		if space.APIKey != "" || space.OAuth != nil {
+			t.Errorf("%s: credentials left in config.yaml after migrating", name)
+		}
+		if _, err := os.Stat(filepath.Join(helperDir, name+".secret")); err != nil {
+			t.Errorf("%s: helper has no secret: %v", name, err)
+		}
+	}
+	keySpace, oauthSpace := cfg.Spaces["main"], cfg.Spaces["oauth"]
+	if err := keySpace.LoadCredentials("main"); err != nil || keySpace.APIKey != "main-key" {
+		t.Errorf("main: LoadCredentials() = %q, %v, want main-key", keySpace.APIKey, err)
+	}
+	if err := oauthSpace.LoadCredentials("oauth"); err != nil || oauthSpace.OAuth == nil || *oauthSpace.OAuth != *oauth {
+		t.Errorf("oauth: LoadCredentials() = %+v, %v, want %+v", oauthSpace.OAuth, err, oauth)
+	}
+
+	// Migrating again is a no-op, and migrating back restores the
+	// credentials to config.yaml and erases them from the helper.
+	runMigrate(t, "--store", config.StoreHelper, "--helper", helper)
+	runMigrate(t, "--store", config.StorePlain)
+
+	cfg, err = config.LoadGlobal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	keySpace, oauthSpace = cfg.Spaces["main"], cfg.Spaces["oauth"]
+	if keySpace.CredentialStore != "" || keySpace.APIKey != "main-key" {
+		t.Errorf("main after migrating back = %+v, want the plain API key", keySpace)
+	}
+	if oauthSpace.CredentialStore != "" || oauthSpace.OAuth == nil || oauthSpace.OAuth.AccessToken != "access" {
+		t.Errorf("oauth after migrating back = %+v, want the plain OAuth token", oauthSpace)
+	}
+	if secrets, _ := filepath.Glob(filepath.Join(helperDir, "*.secret")); len(secrets) != 0 {
+		t.Errorf("helper still holds %v after migrating back", secrets)
+	}
+}
+
+func TestMigrateUnknownStore(t *testing.T) {
+	t.Setenv(config.EnvConfigDir, t.TempDir())
+	cmd := newMigrateCmd()
+	cmd.SetArgs([]string{"--store", "nope"})
+	cmd.SilenceUsage = true
+	if err := cmd.Execute(); err == nil {
+		t.Error("migrate --store nope succeeded, want error")
+	}
+}
@@ -2,51 +2,108 @@ package auth
 
 import (
 	"fmt"
+	"os"
 
 	"github.com/KimMaru10/bl-cli/internal/api"
+	"github.com/KimMaru10/bl-cli/internal/cmdutil"
 	"github.com/KimMaru10/bl-cli/internal/config"
 	"github.com/spf13/cobra"
 )
 
+// spaceStatus is the structured form of one line of bl auth status.
+type spaceStatus struct {
+	Space    string    `json:"space"`
+	SpaceURL string    `json:"spaceUrl"`
+	Current  bool      `json:"current"`
+	Auth     string    `json:"auth"`
+	User     *api.User `json:"user"`
+	Error    string    `json:"error,omitempty"`
+}
+
 func newStatusCmd() *cobra.Command {
-	return &cobra.Command{
+	var exporter *cmdutil.Exporter
+
+	cmd := &cobra.Command{
 		Use:   "status",
 		Short: "認証状態を表示する",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			cfg, err := config.Load()
+			ctx := cmd.Context()
+
+			cfg, err := cmdutil.LoadConfig()
 			if err != nil {
-				return fmt.Errorf("設定の読み込みに失敗しました: %w", err)
+				return err
 			}
 
-			if len(cfg.Spaces) == 0 {
+			if len(cfg.Spaces) == 0 && !exporter.Enabled() {
 				fmt.Println(errorStyle.Render("✗ 未認証です。bl auth login を実行してください"))
 				return nil
 			}
 
 			names := cfg.SpaceNames()
+			statuses := make([]spaceStatus, 0, len(names))
 			for _, name := range names {
 				space := cfg.Spaces[name]
+				st := spaceStatus{
+					Space:    name,
+					SpaceURL: space.SpaceURL,
+					Current:  name == cfg.CurrentName(),
+					Auth:     "apiKey",
+				}
+				if space.AuthType == config.AuthOAuth {
+					st.Auth = "oauth"
+				}
+
+				if err := space.LoadCredentials(name); err != nil {
+					st.Error = err.Error()
+					statuses = append(statuses, st)
+					continue
+				}
+
+				client := cmdutil.NewSpaceClient(name, &space)
+				user, err := client.GetMyself(ctx)
+				if err != nil {
+					st.Error = err.Error()
+				} else {
+					st.User = user
+				}
+				statuses = append(statuses, st)
+			}
+
+			if exporter.Enabled() {
+				return exporter.Write(os.Stdout, statuses)
+			}
+
+			for _, st := range statuses {
 				marker := "  "
-				if name == cfg.CurrentSpace {
+				if st.Current {
 					marker = "* "
 				}
 
-				client := api.NewClient(space.SpaceURL, space.APIKey)
-				user, err := client.GetMyself()
-				if err != nil {
-					fmt.Println(errorStyle.Render(fmt.Sprintf("%s%s (%s) - ✗ 認証が無効です", marker, name, space.SpaceURL)))
+				if st.User == nil {
+					fmt.Println(errorStyle.Render(fmt.Sprintf("%s%s (%s) - ✗ 認証が無効です", marker, st.Space, st.SpaceURL)))
 					continue
 				}
 
-				line := fmt.Sprintf("%s%s (%s) - ✔ %s としてログイン中", marker, name, space.SpaceURL, user.Name)
-				if name == cfg.CurrentSpace {
+				line := fmt.Sprintf("%s%s (%s) - ✔ %s としてログイン中", marker, st.Space, st.SpaceURL, st.User.Name)
+				if st.Auth == "oauth" {
+					line += "（OAuth）"
+				}
+				if st.Current {
 					fmt.Println(successStyle.Render(line))
 				} else {
 					fmt.Println(line)
 				}
 			}
+			if note := pinnedSpaceNote(cfg.CurrentSpace); note != "" && !cmd.Flags().Changed("space") {
+				fmt.Println()
+				fmt.Println(infoStyle.Render("現在のスペースは " + cfg.CurrentSpace + " ですが、" + note))
+			}
 
 			return nil
 		},
 	}
+
+	exporter = cmdutil.AddJSONFlags(cmd, cmdutil.StructFields(spaceStatus{}))
+
+	return cmd
 }
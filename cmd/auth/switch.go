@@ -3,6 +3,7 @@ package auth
 import (
 	"fmt"
 	"io"
+	"os"
 
 	"github.com/KimMaru10/bl-cli/internal/config"
 	"github.com/charmbracelet/bubbles/list"
@@ -83,7 +84,7 @@ func newSwitchCmd() *cobra.Command {
 		Use:   "switch",
 		Short: "使用するスペースを切り替える",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			cfg, err := config.Load()
+			cfg, err := config.LoadGlobal()
 			if err != nil {
 				return fmt.Errorf("設定の読み込みに失敗しました: %w", err)
 			}
@@ -132,7 +133,28 @@ func newSwitchCmd() *cobra.Command {
 			}
 
 			fmt.Println(successStyle.Render("✔ スペースを " + result.selected + " に切り替えました"))
+			if note := pinnedSpaceNote(result.selected); note != "" {
+				fmt.Println(infoStyle.Render("  ただし、" + note))
+			}
 			return nil
 		},
 	}
 }
+
+// pinnedSpaceNote explains why a space other than currentSpace, the
+// current_space in the config file, is used here: BL_SPACE or the space
+// in the repository's .bl.yaml. It returns "" when currentSpace is used.
+func pinnedSpaceNote(currentSpace string) string {
+	if v := os.Getenv(config.EnvSpace); v != "" && v != currentSpace {
+		return "環境変数 " + config.EnvSpace + " の指定により " + v + " が使われます"
+	}
+	p, err := config.FindLocalConfig()
+	if err != nil || p == "" {
+		return ""
+	}
+	local, err := config.ReadLocal(p)
+	if err != nil || local.Space == "" || local.Space == currentSpace {
+		return ""
+	}
+	return p + " の space: で指定されているため、このリポジトリでは " + local.Space + " が使われます"
+}
@@ -0,0 +1,23 @@
+package git
+
+import (
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/cobra"
+)
+
+var (
+	successStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("2"))
+	infoStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("8"))
+)
+
+// NewGitCmd returns the git subcommand group.
+func NewGitCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "git",
+		Short: "git との連携",
+	}
+
+	cmd.AddCommand(newHooksCmd())
+
+	return cmd
+}
@@ -0,0 +1,254 @@
+package git
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/KimMaru10/bl-cli/internal/cmdutil"
+	"github.com/KimMaru10/bl-cli/internal/git"
+	"github.com/spf13/cobra"
+)
+
+// hookNames are the hooks managed by bl git hooks.
+var hookNames = []string{"prepare-commit-msg", "commit-msg"}
+
+// hookMarker identifies hook scripts written by bl.
+const hookMarker = "# Installed by bl-cli."
+
+// backupSuffix is appended to hooks that existed before install.
+const backupSuffix = ".bl-backup"
+
+// hookScript runs the backed-up hook, if any, and then bl itself.
+const hookScript = `#!/bin/sh
+` + hookMarker + ` Run "bl git hooks uninstall" to remove.
+backup="$(dirname "$0")/%[1]s` + backupSuffix + `"
+if [ -x "$backup" ]; then
+	"$backup" "$@" || exit $?
+fi
+exec %[2]s git hooks run %[1]s %[3]s"$@"
+`
+
+func newHooksCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "hooks",
+		Short: "コミットメッセージに課題キーを付ける git フックの管理",
+	}
+
+	cmd.AddCommand(newHooksInstallCmd())
+	cmd.AddCommand(newHooksUninstallCmd())
+	cmd.AddCommand(newHooksRunCmd())
+
+	return cmd
+}
+
+func newHooksInstallCmd() *cobra.Command {
+	var (
+		mode    string
+		require bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "install",
+		Short: "prepare-commit-msg / commit-msg フックをインストールする",
+		Long: `現在のリポジトリに prepare-commit-msg と commit-msg フックをインストールします。
+コミットメッセージに課題キーがなければ、ブランチ名から推測した課題キーを付けます。
+
+既存のフックは ` + backupSuffix + ` を付けて退避し、bl のフックより先に実行されます。
+bl git hooks uninstall で元に戻せます。`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if mode != git.KeyModePrefix && mode != git.KeyModeAppend {
+				return fmt.Errorf("--mode には %s または %s を指定してください", git.KeyModePrefix, git.KeyModeAppend)
+			}
+
+			dir, err := git.HooksDir()
+			if err != nil {
+				return fmt.Errorf("git リポジトリではありません: %w", err)
+			}
+			if err := os.MkdirAll(dir, 0755); err != nil {
+				return fmt.Errorf("フックディレクトリの作成に失敗しました: %w", err)
+			}
+
+			bl, err := os.Executable()
+			if err != nil {
+				return fmt.Errorf("bl の実行パスの取得に失敗しました: %w", err)
+			}
+
+			flags := "--mode " + mode + " "
+			if require {
+				flags += "--require "
+			}
+
+			for _, name := range hookNames {
+				p := filepath.Join(dir, name)
+				installed, err := isBLHook(p)
+				if err != nil {
+					return err
+				}
+				if !installed {
+					backedUp, err := backupHook(p)
+					if err != nil {
+						return err
+					}
+					if backedUp {
+						fmt.Println(infoStyle.Render("  既存の " + name + " を " + name + backupSuffix + " に退避しました"))
+					}
+				}
+
+				script := fmt.Sprintf(hookScript, name, shellQuote(bl), flags)
+				if err := os.WriteFile(p, []byte(script), 0755); err != nil {
+					return fmt.Errorf("%s の書き込みに失敗しました: %w", name, err)
+				}
+			}
+
+			fmt.Println(successStyle.Render("✔ " + dir + " にフックをインストールしました"))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&mode, "mode", git.KeyModePrefix, "課題キーを件名の前に付ける（prefix）か後ろに付ける（append）か")
+	cmd.Flags().BoolVar(&require, "require", false, "課題キーのないコミットを拒否する")
+
+	return cmd
+}
+
+func newHooksUninstallCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "uninstall",
+		Short: "bl の git フックを削除し、退避したフックを元に戻す",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dir, err := git.HooksDir()
+			if err != nil {
+				return fmt.Errorf("git リポジトリではありません: %w", err)
+			}
+
+			for _, name := range hookNames {
+				p := filepath.Join(dir, name)
+				installed, err := isBLHook(p)
+				if err != nil {
+					return err
+				}
+				if !installed {
+					if _, err := os.Stat(p); err == nil {
+						fmt.Println(infoStyle.Render("  " + name + " は bl のフックではないため残しました"))
+					}
+					continue
+				}
+
+				if err := os.Remove(p); err != nil {
+					return fmt.Errorf("%s の削除に失敗しました: %w", name, err)
+				}
+				if _, err := os.Stat(p + backupSuffix); err == nil {
+					if err := os.Rename(p+backupSuffix, p); err != nil {
+						return fmt.Errorf("%s の復元に失敗しました: %w", name, err)
+					}
+					fmt.Println(infoStyle.Render("  退避していた " + name + " を元に戻しました"))
+				}
+			}
+
+			fmt.Println(successStyle.Render("✔ フックをアンインストールしました"))
+			return nil
+		},
+	}
+}
+
+func newHooksRunCmd() *cobra.Command {
+	var (
+		mode    string
+		require bool
+	)
+
+	cmd := &cobra.Command{
+		Use:    "run <hook> <file> [args...]",
+		Short:  "git フックから呼び出される",
+		Hidden: true,
+		Args:   cobra.MinimumNArgs(2),
+		// Keep git's output readable when a commit is rejected
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			hook, file := args[0], args[1]
+			if !slices.Contains(hookNames, hook) {
+				return fmt.Errorf("不明なフックです: %s", hook)
+			}
+
+			data, err := os.ReadFile(file)
+			if err != nil {
+				return fmt.Errorf("コミットメッセージの読み込みに失敗しました: %w", err)
+			}
+			msg := string(data)
+
+			// An empty message aborts the commit; adding a key would
+			// commit it instead
+			content := git.MessageContent(msg)
+			if content == "" {
+				return nil
+			}
+
+			cfg, err := cmdutil.LoadConfig()
+			if err != nil {
+				return err
+			}
+			m, err := cmdutil.IssueKeyMatcher(cfg)
+			if err != nil {
+				return err
+			}
+
+			if len(m.FindAll(content)) == 0 {
+				if branch, err := git.GetCurrentBranch(); err == nil {
+					if keys := m.FindAll(branch); len(keys) > 0 {
+						msg = git.AddIssueKey(msg, keys[0], mode)
+						if err := os.WriteFile(file, []byte(msg), 0644); err != nil {
+							return fmt.Errorf("コミットメッセージの書き込みに失敗しました: %w", err)
+						}
+						return nil
+					}
+				}
+				if require && hook == "commit-msg" {
+					return fmt.Errorf("コミットメッセージに課題キーがありません（課題キーを含むブランチで作業するか、メッセージに記載してください）")
+				}
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&mode, "mode", git.KeyModePrefix, "")
+	cmd.Flags().BoolVar(&require, "require", false, "")
+
+	return cmd
+}
+
+// isBLHook reports whether the hook at p was written by bl.
+func isBLHook(p string) (bool, error) {
+	data, err := os.ReadFile(p)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("%s の読み込みに失敗しました: %w", p, err)
+	}
+	return strings.Contains(string(data), hookMarker), nil
+}
+
+// backupHook moves an existing hook aside so the bl hook can chain to it.
+// It reports whether there was a hook to back up.
+func backupHook(p string) (bool, error) {
+	if _, err := os.Stat(p); os.IsNotExist(err) {
+		return false, nil
+	}
+	if _, err := os.Stat(p + backupSuffix); err == nil {
+		return false, fmt.Errorf("%s が既に存在します。確認してから削除してください", p+backupSuffix)
+	}
+	if err := os.Rename(p, p+backupSuffix); err != nil {
+		return false, fmt.Errorf("%s の退避に失敗しました: %w", p, err)
+	}
+	return true, nil
+}
+
+// shellQuote quotes s for use in a POSIX shell script.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
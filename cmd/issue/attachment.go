@@ -0,0 +1,255 @@
+package issue
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+
+	"github.com/KimMaru10/bl-cli/internal/api"
+	"github.com/KimMaru10/bl-cli/internal/cmdutil"
+	"github.com/KimMaru10/bl-cli/internal/tui"
+	"github.com/spf13/cobra"
+)
+
+func newAttachmentCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "attachment",
+		Aliases: []string{"attachments"},
+		Short:   "課題の添付ファイルを管理する",
+		Long: `課題の添付ファイルを一覧・ダウンロード・削除します。
+
+ファイルを添付するには bl issue create / edit / comment の --attach を使用します。`,
+	}
+
+	cmd.AddCommand(newAttachmentListCmd())
+	cmd.AddCommand(newAttachmentDownloadCmd())
+	cmd.AddCommand(newAttachmentDeleteCmd())
+
+	return cmd
+}
+
+func newAttachmentListCmd() *cobra.Command {
+	var exporter *cmdutil.Exporter
+
+	cmd := &cobra.Command{
+		Use:   "list [issueKey]",
+		Short: "添付ファイル一覧を表示する",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			cfg, client, err := cmdutil.LoadConfigAndClient()
+			if err != nil {
+				return err
+			}
+
+			issueKey, err := cmdutil.ResolveIssueKey(cfg, args)
+			if err != nil {
+				return err
+			}
+
+			attachments, err := client.GetIssueAttachments(ctx, issueKey)
+			if err != nil {
+				return err
+			}
+
+			if exporter.Enabled() {
+				return exporter.Write(os.Stdout, attachments)
+			}
+
+			if len(attachments) == 0 {
+				fmt.Println("添付ファイルはありません")
+				return nil
+			}
+
+			for _, a := range attachments {
+				userName := ""
+				if a.CreatedUser != nil {
+					userName = a.CreatedUser.Name
+				}
+				fmt.Printf("%-10d %s  %s\n", a.ID, a.Name, labelStyle.Render(formatSize(a.Size)+" — "+userName+" "+a.Created))
+			}
+			return nil
+		},
+	}
+
+	exporter = cmdutil.AddJSONFlags(cmd, cmdutil.StructFields(api.Attachment{}))
+
+	return cmd
+}
+
+func newAttachmentDownloadCmd() *cobra.Command {
+	var (
+		dir    string
+		output string
+		force  bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "download <issueKey> [attachmentID...]",
+		Short: "添付ファイルをダウンロードする",
+		Long: `課題の添付ファイルをダウンロードします。
+
+添付ファイル ID を省略するとすべての添付ファイルをダウンロードします。
+既存のファイルは --force を指定しない限り上書きしません。`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			issueKey := args[0]
+			var ids []int
+			for _, arg := range args[1:] {
+				id, err := strconv.Atoi(arg)
+				if err != nil {
+					return fmt.Errorf("添付ファイル ID は数値で指定してください: %s", arg)
+				}
+				ids = append(ids, id)
+			}
+
+			_, client, err := cmdutil.LoadConfigAndClient()
+			if err != nil {
+				return err
+			}
+
+			attachments, err := client.GetIssueAttachments(ctx, issueKey)
+			if err != nil {
+				return err
+			}
+			targets, err := selectAttachments(attachments, ids)
+			if err != nil {
+				return err
+			}
+			if len(targets) == 0 {
+				fmt.Println("添付ファイルはありません")
+				return nil
+			}
+			if output != "" && len(targets) > 1 {
+				return fmt.Errorf("--output は添付ファイルを 1 つだけダウンロードする場合に指定してください")
+			}
+
+			for _, a := range targets {
+				path := output
+				if path == "" {
+					// Attachment names come from other users; never let them
+					// escape the destination directory.
+					name := filepath.Base(a.Name)
+					if name == "." || name == ".." || name == string(filepath.Separator) {
+						name = "attachment-" + strconv.Itoa(a.ID)
+					}
+					path = filepath.Join(dir, name)
+				}
+				if !force {
+					if _, err := os.Stat(path); err == nil {
+						return fmt.Errorf("%s は既に存在します（上書きするには --force を指定してください）", path)
+					}
+				}
+
+				data, err := client.DownloadIssueAttachment(ctx, issueKey, a.ID)
+				if err != nil {
+					return err
+				}
+				if err := os.WriteFile(path, data, 0644); err != nil {
+					return fmt.Errorf("%s の書き込みに失敗しました: %w", path, err)
+				}
+				fmt.Println(successStyle.Render("✔ " + path + " を保存しました（" + formatSize(int64(len(data))) + "）"))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&dir, "dir", "D", ".", "保存先ディレクトリ")
+	cmd.Flags().StringVarP(&output, "output", "o", "", "保存先ファイル名（1 ファイルのみ）")
+	cmd.Flags().BoolVarP(&force, "force", "f", false, "既存のファイルを上書きする")
+
+	return cmd
+}
+
+func newAttachmentDeleteCmd() *cobra.Command {
+	var yes bool
+
+	cmd := &cobra.Command{
+		Use:   "delete <issueKey> <attachmentID>",
+		Short: "添付ファイルを削除する",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			issueKey := args[0]
+			id, err := strconv.Atoi(args[1])
+			if err != nil {
+				return fmt.Errorf("添付ファイル ID は数値で指定してください: %s", args[1])
+			}
+
+			_, client, err := cmdutil.LoadConfigAndClient()
+			if err != nil {
+				return err
+			}
+
+			if !yes {
+				if !tui.Interactive() {
+					return errConfirmRequired
+				}
+				attachments, err := client.GetIssueAttachments(ctx, issueKey)
+				if err != nil {
+					return err
+				}
+				targets, err := selectAttachments(attachments, []int{id})
+				if err != nil {
+					return err
+				}
+
+				fmt.Println(titleStyle.Render(issueKey + " " + targets[0].Name))
+				fmt.Println(warningStyle.Render("この添付ファイルを削除します。削除したファイルは元に戻せません。"))
+				if !confirmByTyping(issueKey) {
+					fmt.Println("キャンセルしました")
+					return nil
+				}
+			}
+
+			deleted, err := client.DeleteIssueAttachment(ctx, issueKey, id)
+			if err != nil {
+				return err
+			}
+
+			fmt.Println(successStyle.Render("✔ " + issueKey + " から " + deleted.Name + " を削除しました"))
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "確認せずに削除する")
+
+	return cmd
+}
+
+// selectAttachments returns the attachments with the given IDs, or all of
+// them when ids is empty.
+func selectAttachments(attachments []api.Attachment, ids []int) ([]api.Attachment, error) {
+	if len(ids) == 0 {
+		return attachments, nil
+	}
+	var selected []api.Attachment
+	for _, id := range ids {
+		i := slices.IndexFunc(attachments, func(a api.Attachment) bool { return a.ID == id })
+		if i < 0 {
+			return nil, fmt.Errorf("添付ファイル %d が見つかりません", id)
+		}
+		selected = append(selected, attachments[i])
+	}
+	return selected, nil
+}
+
+// formatSize formats a byte count for display, e.g. 1.2 MB.
+func formatSize(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(n)/float64(div), "KMGT"[exp])
+}
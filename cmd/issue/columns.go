@@ -0,0 +1,121 @@
+package issue
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/KimMaru10/bl-cli/internal/api"
+	"github.com/KimMaru10/bl-cli/internal/cmdutil"
+)
+
+// listColumn is a column of the bl issue list table.
+type listColumn struct {
+	name   string
+	header string
+	value  func(api.Issue) string
+}
+
+// defaultListColumns are shown when --columns is not given.
+var defaultListColumns = []string{"key", "status", "assignee", "title"}
+
+var listColumns = []listColumn{
+	{"key", "KEY", func(i api.Issue) string { return i.IssueKey }},
+	{"status", "STATUS", func(i api.Issue) string {
+		if i.Status == nil {
+			return ""
+		}
+		return statusColor(i.Status.Name).Render(i.Status.Name)
+	}},
+	{"assignee", "ASSIGNEE", func(i api.Issue) string {
+		if i.Assignee == nil {
+			return ""
+		}
+		return i.Assignee.Name
+	}},
+	{"title", "TITLE", func(i api.Issue) string { return i.Summary }},
+	{"type", "TYPE", func(i api.Issue) string {
+		if i.IssueType == nil {
+			return ""
+		}
+		return i.IssueType.Name
+	}},
+	{"priority", "PRIORITY", func(i api.Issue) string {
+		if i.Priority == nil {
+			return ""
+		}
+		return i.Priority.Name
+	}},
+	{"milestone", "MILESTONE", func(i api.Issue) string {
+		names := make([]string, len(i.Milestone))
+		for j, m := range i.Milestone {
+			names[j] = m.Name
+		}
+		return strings.Join(names, ",")
+	}},
+	{"category", "CATEGORY", func(i api.Issue) string {
+		names := make([]string, len(i.Category))
+		for j, c := range i.Category {
+			names[j] = c.Name
+		}
+		return strings.Join(names, ",")
+	}},
+	{"author", "AUTHOR", func(i api.Issue) string {
+		if i.CreatedUser == nil {
+			return ""
+		}
+		return i.CreatedUser.Name
+	}},
+	{"start", "START", func(i api.Issue) string { return formatDate(i.StartDate) }},
+	{"due", "DUE", func(i api.Issue) string { return formatDate(i.DueDate) }},
+	{"created", "CREATED", func(i api.Issue) string { return formatDate(i.Created) }},
+	{"updated", "UPDATED", func(i api.Issue) string { return formatDate(i.Updated) }},
+	{"estimated", "ESTIMATED", func(i api.Issue) string {
+		if i.EstimatedHours == nil {
+			return ""
+		}
+		return cmdutil.FormatHours(*i.EstimatedHours)
+	}},
+	{"actual", "ACTUAL", func(i api.Issue) string {
+		if i.ActualHours == nil {
+			return ""
+		}
+		return cmdutil.FormatHours(*i.ActualHours)
+	}},
+}
+
+// listColumnNames returns the names accepted by --columns.
+func listColumnNames() []string {
+	names := make([]string, len(listColumns))
+	for i, c := range listColumns {
+		names[i] = c.name
+	}
+	return names
+}
+
+// listColumnsByName returns the named columns in order, or the default
+// columns if names is empty.
+func listColumnsByName(names []string) ([]listColumn, error) {
+	if len(names) == 0 {
+		names = defaultListColumns
+	}
+	cols := make([]listColumn, 0, len(names))
+	for _, name := range names {
+		key := strings.ToLower(strings.TrimSpace(name))
+		i := slices.IndexFunc(listColumns, func(c listColumn) bool { return c.name == key })
+		if i < 0 {
+			return nil, fmt.Errorf("不明な列です: %s（%s）", name, strings.Join(listColumnNames(), ", "))
+		}
+		cols = append(cols, listColumns[i])
+	}
+	return cols, nil
+}
+
+// formatDate trims a Backlog timestamp such as 2026-11-01T00:00:00Z to
+// the date.
+func formatDate(s string) string {
+	if len(s) >= len("2006-01-02") {
+		return s[:len("2006-01-02")]
+	}
+	return s
+}
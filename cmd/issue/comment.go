@@ -1,14 +1,13 @@
 package issue
 
 import (
-	"bufio"
 	"fmt"
 	"os"
-	"os/exec"
-	"path/filepath"
 	"strings"
 
+	"github.com/KimMaru10/bl-cli/internal/api"
 	"github.com/KimMaru10/bl-cli/internal/cmdutil"
+	"github.com/KimMaru10/bl-cli/internal/tui"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/spf13/cobra"
 )
@@ -19,22 +18,50 @@ var (
 )
 
 func newCommentCmd() *cobra.Command {
-	var body string
+	var (
+		body         string
+		notify       []string
+		notifySelect bool
+		attach       []string
+	)
 
 	cmd := &cobra.Command{
 		Use:   "comment [issueKey]",
 		Short: "課題にコメントを追加する",
-		Args:  cobra.MaximumNArgs(1),
+		Long: `課題にコメントを追加します。
+
+--notify で指定したユーザーと、本文中で @ユーザーID（または @名前）と
+メンションしたプロジェクトメンバーに通知が送られます。--notify-select を
+付けると通知先を一覧から選択できます。エディタのテンプレートには
+メンバーの一覧が記載されるため、エディタの補完機能で @ユーザーID を入力できます。`,
+		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			_, client, err := cmdutil.LoadConfigAndClient()
+			ctx := cmd.Context()
+
+			cfg, client, err := cmdutil.LoadConfigAndClient()
 			if err != nil {
 				return err
 			}
 
-			issueKey, err := resolveIssueKey(args)
+			issueKey, err := cmdutil.ResolveIssueKey(cfg, args)
 			if err != nil {
 				return err
 			}
+			var users []api.User
+			loadUsers := func() ([]api.User, error) {
+				if users == nil {
+					projectKey, err := cmdutil.IssueProjectKey(ctx, client, issueKey)
+					if err != nil {
+						return nil, err
+					}
+					u, err := client.GetProjectUsers(ctx, projectKey)
+					if err != nil {
+						return nil, err
+					}
+					users = u
+				}
+				return users, nil
+			}
 
 			var content string
 
@@ -42,39 +69,16 @@ func newCommentCmd() *cobra.Command {
 				// Inline mode
 				content = body
 			} else {
-				// Editor mode
-				editor := os.Getenv("EDITOR")
-				if editor == "" {
-					editor = "vim"
-				}
-
-				tmpDir := os.TempDir()
-				tmpFile := filepath.Join(tmpDir, "bl-comment-"+issueKey+".md")
-
-				template := "# 1行目以降にコメントを入力してください。# で始まる行は無視されます\n"
-				if err := os.WriteFile(tmpFile, []byte(template), 0644); err != nil {
-					return fmt.Errorf("一時ファイルの作成に失敗しました: %w", err)
-				}
-				defer os.Remove(tmpFile)
-
-				editorCmd := exec.Command(editor, tmpFile)
-				editorCmd.Stdin = os.Stdin
-				editorCmd.Stdout = os.Stdout
-				editorCmd.Stderr = os.Stderr
-				if err := editorCmd.Run(); err != nil {
-					return fmt.Errorf("エディタの起動に失敗しました: %w", err)
-				}
-
-				file, err := os.Open(tmpFile)
+				// Editor mode. The member list is only a hint, so the
+				// editor still opens without it if it cannot be fetched.
+				members, _ := loadUsers()
+				text, err := editInEditor("bl-comment-"+issueKey+".md", commentTemplate(members))
 				if err != nil {
-					return fmt.Errorf("一時ファイルの読み込みに失敗しました: %w", err)
+					return err
 				}
-				defer file.Close()
 
 				var lines []string
-				scanner := bufio.NewScanner(file)
-				for scanner.Scan() {
-					line := scanner.Text()
+				for _, line := range strings.Split(text, "\n") {
 					if !strings.HasPrefix(line, "#") {
 						lines = append(lines, line)
 					}
@@ -87,47 +91,136 @@ func newCommentCmd() *cobra.Command {
 				return nil
 			}
 
-			_, err = client.AddComment(issueKey, content)
+			opts := &api.AddCommentOptions{Content: content}
+			if len(notify) > 0 || notifySelect || strings.Contains(content, "@") {
+				members, err := loadUsers()
+				if err != nil {
+					return err
+				}
+				notified, err := api.FindUsers(members, notify)
+				if err != nil {
+					return err
+				}
+				notified = append(notified, api.MentionedUsers(members, content)...)
+
+				if notifySelect {
+					selected, ok := selectNotifiedUsers(members, notified)
+					if !ok {
+						fmt.Println("キャンセルしました")
+						return nil
+					}
+					notified = selected
+				}
+				opts.NotifiedUserIDs = api.UserIDs(notified)
+			}
+
+			if len(attach) > 0 {
+				opts.AttachmentIDs, err = cmdutil.UploadFiles(ctx, client, attach)
+				if err != nil {
+					return err
+				}
+			}
+
+			_, err = client.AddComment(ctx, issueKey, opts)
 			if err != nil {
 				return err
 			}
 
-			fmt.Println(successStyle.Render("✔ " + issueKey + " にコメントを追加しました"))
+			msg := "✔ " + issueKey + " にコメントを追加しました"
+			if n := len(opts.NotifiedUserIDs); n > 0 {
+				msg += fmt.Sprintf("（%d 人に通知）", n)
+			}
+			fmt.Println(successStyle.Render(msg))
 			return nil
 		},
 	}
 
 	cmd.Flags().StringVarP(&body, "body", "b", "", "コメント本文")
+	cmd.Flags().StringSliceVarP(&notify, "notify", "n", nil, "通知するユーザー名またはユーザーID（カンマ区切り）")
+	cmd.Flags().BoolVar(&notifySelect, "notify-select", false, "通知するユーザーを一覧から選択する")
+	cmd.Flags().StringSliceVar(&attach, "attach", nil, "添付するファイル（複数指定可）")
 
 	// Add list subcommand
 	cmd.AddCommand(newCommentListCmd())
+	cmd.AddCommand(newCommentEditCmd())
+	cmd.AddCommand(newCommentDeleteCmd())
 
 	return cmd
 }
 
+// commentTemplate returns the initial editor text for a new comment. The
+// project members are listed so that @userId can be completed from the
+// buffer by the editor.
+func commentTemplate(members []api.User) string {
+	var b strings.Builder
+	b.WriteString("# 1行目以降にコメントを入力してください。# で始まる行は無視されます\n")
+	if len(members) == 0 {
+		return b.String()
+	}
+	b.WriteString("# @ユーザーID でメンションしたメンバーに通知されます:\n")
+	for _, u := range members {
+		if u.UserID == "" {
+			continue
+		}
+		fmt.Fprintf(&b, "#   @%s (%s)\n", u.UserID, u.Name)
+	}
+	return b.String()
+}
+
+// selectNotifiedUsers lets the user pick who to notify, starting with the
+// users already chosen through --notify or mentions checked.
+func selectNotifiedUsers(members, chosen []api.User) ([]api.User, bool) {
+	items := make([]tui.SelectItem, len(members))
+	for i, u := range members {
+		items[i] = tui.SelectItem{ID: u.ID, Label: u.Name}
+	}
+	selected, ok := tui.MultiSelect("通知するユーザーを選択", items, api.UserIDs(chosen))
+	if !ok {
+		return nil, false
+	}
+	var users []api.User
+	for _, item := range selected {
+		for _, u := range members {
+			if u.ID == item.ID {
+				users = append(users, u)
+			}
+		}
+	}
+	return users, true
+}
+
 func newCommentListCmd() *cobra.Command {
-	var count int
+	var (
+		count    int
+		exporter *cmdutil.Exporter
+	)
 
 	cmd := &cobra.Command{
 		Use:   "list [issueKey]",
 		Short: "コメント一覧を表示する",
 		Args:  cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			_, client, err := cmdutil.LoadConfigAndClient()
+			ctx := cmd.Context()
+
+			cfg, client, err := cmdutil.LoadConfigAndClient()
 			if err != nil {
 				return err
 			}
 
-			issueKey, err := resolveIssueKey(args)
+			issueKey, err := cmdutil.ResolveIssueKey(cfg, args)
 			if err != nil {
 				return err
 			}
 
-			comments, err := client.GetComments(issueKey, count, "desc")
+			comments, err := client.GetComments(ctx, issueKey, count, "desc")
 			if err != nil {
 				return err
 			}
 
+			if exporter.Enabled() {
+				return exporter.Write(os.Stdout, comments)
+			}
+
 			if len(comments) == 0 {
 				fmt.Println("コメントはありません")
 				return nil
@@ -144,8 +237,11 @@ func newCommentListCmd() *cobra.Command {
 				header := commentHeaderStyle.Render(userName + " — " + c.Created)
 				fmt.Println(header)
 
-				// Show change logs
+				// Show change logs. Added attachments are listed below.
 				for _, cl := range c.ChangeLog {
+					if cl.Field == "attachment" && cl.AttachmentInfo != nil && cl.NewValue != "" {
+						continue
+					}
 					if cl.OriginalValue != "" && cl.NewValue != "" {
 						fmt.Println(changeStyle.Render(fmt.Sprintf("  %s：%s → %s", cl.Field, cl.OriginalValue, cl.NewValue)))
 					} else if cl.NewValue != "" {
@@ -165,6 +261,14 @@ func newCommentListCmd() *cobra.Command {
 					}
 				}
 
+				if attachments := c.Attachments(); len(attachments) > 0 {
+					names := make([]string, len(attachments))
+					for j, a := range attachments {
+						names[j] = fmt.Sprintf("%s (%d)", a.Name, a.ID)
+					}
+					fmt.Println(labelStyle.Render("attachment") + "：" + strings.Join(names, ", "))
+				}
+
 				if i < len(comments)-1 {
 					fmt.Println(separatorStyle.Render("---"))
 				}
@@ -174,6 +278,7 @@ func newCommentListCmd() *cobra.Command {
 	}
 
 	cmd.Flags().IntVarP(&count, "count", "c", 10, "表示件数")
+	exporter = cmdutil.AddJSONFlags(cmd, cmdutil.StructFields(api.Comment{}))
 
 	return cmd
 }
@@ -0,0 +1,119 @@
+package issue
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/KimMaru10/bl-cli/internal/api"
+	"github.com/KimMaru10/bl-cli/internal/cmdutil"
+	"github.com/spf13/cobra"
+)
+
+// maxCommentsForLast is how many recent comments --last looks through.
+const maxCommentsForLast = 100
+
+func newCommentEditCmd() *cobra.Command {
+	var (
+		body string
+		last bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "edit <issueKey> <commentID>",
+		Short: "コメントを編集する",
+		Long: `既存のコメントをエディタで編集します。--body を指定するとエディタを開かずに置き換えます。
+--last を指定すると、課題に対する自分の最新のコメントを編集します（課題キーは省略可能）。`,
+		Example: `  bl issue comment edit PROJ-123 456
+  bl issue comment edit PROJ-123 --last
+  bl issue comment edit --last --body "修正後の本文"`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if last {
+				return cobra.MaximumNArgs(1)(cmd, args)
+			}
+			return cobra.ExactArgs(2)(cmd, args)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			cfg, client, err := cmdutil.LoadConfigAndClient()
+			if err != nil {
+				return err
+			}
+
+			var comment *api.Comment
+			var issueKey string
+			if last {
+				issueKey, err = cmdutil.ResolveIssueKey(cfg, args)
+				if err != nil {
+					return err
+				}
+				comment, err = findLastOwnComment(ctx, client, issueKey)
+				if err != nil {
+					return err
+				}
+			} else {
+				issueKey = args[0]
+				commentID, err := strconv.Atoi(args[1])
+				if err != nil {
+					return fmt.Errorf("コメント ID は数値で指定してください: %s", args[1])
+				}
+				comment, err = client.GetComment(ctx, issueKey, commentID)
+				if err != nil {
+					return err
+				}
+			}
+
+			content := body
+			if content == "" {
+				text, err := editInEditor(fmt.Sprintf("bl-comment-%s-%d.md", issueKey, comment.ID), comment.Content)
+				if err != nil {
+					return err
+				}
+				content = strings.TrimSpace(text)
+			}
+
+			if content == "" {
+				fmt.Println("コメントが空のため中止しました（削除する場合は bl issue comment delete を使用してください）")
+				return nil
+			}
+			if content == strings.TrimSpace(comment.Content) {
+				fmt.Println("変更がないため中止しました")
+				return nil
+			}
+
+			if _, err := client.UpdateComment(ctx, issueKey, comment.ID, content); err != nil {
+				return err
+			}
+
+			fmt.Println(successStyle.Render(fmt.Sprintf("✔ %s のコメント %d を更新しました", issueKey, comment.ID)))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&body, "body", "b", "", "新しいコメント本文")
+	cmd.Flags().BoolVar(&last, "last", false, "自分の最新のコメントを編集する")
+
+	return cmd
+}
+
+// findLastOwnComment returns the most recent comment on issueKey posted by
+// the authenticated user. Change-log-only entries have no content and are
+// skipped.
+func findLastOwnComment(ctx context.Context, client *api.Client, issueKey string) (*api.Comment, error) {
+	me, err := client.GetMyself(ctx)
+	if err != nil {
+		return nil, err
+	}
+	comments, err := client.GetComments(ctx, issueKey, maxCommentsForLast, "desc")
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range comments {
+		if c.CreatedUser != nil && c.CreatedUser.ID == me.ID && c.Content != "" {
+			return &c, nil
+		}
+	}
+	return nil, fmt.Errorf("%s に自分のコメントが見つかりません", issueKey)
+}
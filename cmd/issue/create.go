@@ -1,6 +1,7 @@
 package issue
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/KimMaru10/bl-cli/internal/api"
@@ -22,12 +23,18 @@ func newCreateCmd() *cobra.Command {
 		dueDate     string
 		milestone   string
 		project     string
+		attach      []string
+		fields      []string
+		parent      string
+		estimate    string
 	)
 
 	cmd := &cobra.Command{
 		Use:   "create",
 		Short: "課題を作成する",
 		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
 			cfg, client, err := cmdutil.LoadConfigAndClient()
 			if err != nil {
 				return err
@@ -43,7 +50,7 @@ func newCreateCmd() *cobra.Command {
 				return fmt.Errorf("プロジェクトを指定してください（--project または bl project set）")
 			}
 
-			proj, err := client.GetProject(projectKey)
+			proj, err := client.GetProject(ctx, projectKey)
 			if err != nil {
 				return err
 			}
@@ -52,6 +59,41 @@ func newCreateCmd() *cobra.Command {
 				ProjectID: proj.ID,
 			}
 
+			fieldArgs, err := cmdutil.ParseFieldArgs(fields)
+			if err != nil {
+				return err
+			}
+
+			if estimate != "" {
+				opts.EstimatedHours, err = cmdutil.ParseHours(estimate)
+				if err != nil {
+					return err
+				}
+			}
+
+			if parent != "" {
+				parentIssue, err := client.GetIssue(ctx, parent)
+				if err != nil {
+					return err
+				}
+				if parentIssue.ProjectID != proj.ID {
+					return fmt.Errorf("親課題 %s は別のプロジェクトの課題です", parentIssue.IssueKey)
+				}
+				if parentIssue.ParentIssueID != nil {
+					return fmt.Errorf("%s は子課題のため親課題に指定できません", parentIssue.IssueKey)
+				}
+				opts.ParentIssueID = parentIssue.ID
+			}
+
+			// Fall back to the defaults in .bl.yaml
+			local := cfg.Local()
+			if typeName == "" {
+				typeName = local.IssueType
+			}
+			if priority == "" {
+				priority = local.Priority
+			}
+
 			if summary != "" {
 				// Flag mode
 				if typeName == "" || priority == "" {
@@ -62,36 +104,17 @@ func newCreateCmd() *cobra.Command {
 				opts.Description = description
 				opts.DueDate = dueDate
 
-				issueTypes, err := client.GetIssueTypes(projectKey)
+				opts.IssueTypeID, err = findIssueTypeID(ctx, client, projectKey, typeName)
 				if err != nil {
 					return err
 				}
-				for _, t := range issueTypes {
-					if t.Name == typeName {
-						opts.IssueTypeID = t.ID
-						break
-					}
-				}
-				if opts.IssueTypeID == 0 {
-					return fmt.Errorf("課題種別 '%s' が見つかりません", typeName)
-				}
-
-				priorities, err := client.GetPriorities()
+				opts.PriorityID, err = findPriorityID(ctx, client, priority)
 				if err != nil {
 					return err
 				}
-				for _, p := range priorities {
-					if p.Name == priority {
-						opts.PriorityID = p.ID
-						break
-					}
-				}
-				if opts.PriorityID == 0 {
-					return fmt.Errorf("優先度 '%s' が見つかりません", priority)
-				}
 
 				if assignee != "" {
-					users, err := client.GetProjectUsers(projectKey)
+					users, err := client.GetProjectUsers(ctx, projectKey)
 					if err != nil {
 						return err
 					}
@@ -104,7 +127,7 @@ func newCreateCmd() *cobra.Command {
 				}
 
 				if milestone != "" {
-					milestones, err := client.GetMilestones(projectKey)
+					milestones, err := client.GetMilestones(ctx, projectKey)
 					if err != nil {
 						return err
 					}
@@ -115,6 +138,17 @@ func newCreateCmd() *cobra.Command {
 						}
 					}
 				}
+
+				if len(fieldArgs) > 0 {
+					defs, err := client.GetCustomFields(ctx, projectKey)
+					if err != nil {
+						return err
+					}
+					opts.CustomFields, err = cmdutil.CustomFieldParams(defs, fieldArgs)
+					if err != nil {
+						return err
+					}
+				}
 			} else {
 				// Interactive mode
 				s, ok := tui.Input("タイトル: ", "課題のタイトルを入力")
@@ -124,37 +158,51 @@ func newCreateCmd() *cobra.Command {
 				opts.Summary = s
 
 				// Issue type
-				issueTypes, err := client.GetIssueTypes(projectKey)
-				if err != nil {
-					return err
-				}
-				typeItems := make([]tui.SelectItem, len(issueTypes))
-				for i, t := range issueTypes {
-					typeItems[i] = tui.SelectItem{ID: t.ID, Label: t.Name}
-				}
-				selected := tui.Select("課題種別を選択", typeItems)
-				if selected == nil {
-					return nil
+				if typeName != "" {
+					opts.IssueTypeID, err = findIssueTypeID(ctx, client, projectKey, typeName)
+					if err != nil {
+						return err
+					}
+				} else {
+					issueTypes, err := client.GetIssueTypes(ctx, projectKey)
+					if err != nil {
+						return err
+					}
+					typeItems := make([]tui.SelectItem, len(issueTypes))
+					for i, t := range issueTypes {
+						typeItems[i] = tui.SelectItem{ID: t.ID, Label: t.Name}
+					}
+					selected := tui.Select("課題種別を選択", typeItems)
+					if selected == nil {
+						return nil
+					}
+					opts.IssueTypeID = selected.ID
 				}
-				opts.IssueTypeID = selected.ID
 
 				// Priority
-				priorities, err := client.GetPriorities()
-				if err != nil {
-					return err
-				}
-				prioItems := make([]tui.SelectItem, len(priorities))
-				for i, p := range priorities {
-					prioItems[i] = tui.SelectItem{ID: p.ID, Label: p.Name}
-				}
-				selected = tui.Select("優先度を選択", prioItems)
-				if selected == nil {
-					return nil
+				if priority != "" {
+					opts.PriorityID, err = findPriorityID(ctx, client, priority)
+					if err != nil {
+						return err
+					}
+				} else {
+					priorities, err := client.GetPriorities(ctx)
+					if err != nil {
+						return err
+					}
+					prioItems := make([]tui.SelectItem, len(priorities))
+					for i, p := range priorities {
+						prioItems[i] = tui.SelectItem{ID: p.ID, Label: p.Name}
+					}
+					selected := tui.Select("優先度を選択", prioItems)
+					if selected == nil {
+						return nil
+					}
+					opts.PriorityID = selected.ID
 				}
-				opts.PriorityID = selected.ID
 
 				// Assignee
-				users, err := client.GetProjectUsers(projectKey)
+				users, err := client.GetProjectUsers(ctx, projectKey)
 				if err != nil {
 					return err
 				}
@@ -162,7 +210,7 @@ func newCreateCmd() *cobra.Command {
 				for _, u := range users {
 					userItems = append(userItems, tui.SelectItem{ID: u.ID, Label: u.Name})
 				}
-				selected = tui.Select("担当者を選択", userItems)
+				selected := tui.Select("担当者を選択", userItems)
 				if selected == nil {
 					return nil
 				}
@@ -184,6 +232,28 @@ func newCreateCmd() *cobra.Command {
 				}
 				opts.Description = desc
 
+				// Custom fields not given with --field
+				defs, err := client.GetCustomFields(ctx, projectKey)
+				if err != nil {
+					return err
+				}
+				opts.CustomFields, err = cmdutil.CustomFieldParams(defs, fieldArgs)
+				if err != nil {
+					return err
+				}
+				for _, def := range defs {
+					if _, ok := opts.CustomFields[def.ID]; ok || !def.AppliesTo(opts.IssueTypeID) {
+						continue
+					}
+					values, ok := promptCustomField(def, nil)
+					if !ok {
+						return nil
+					}
+					if len(values) > 0 {
+						opts.CustomFields[def.ID] = values
+					}
+				}
+
 				// Confirm
 				if !tui.Confirm("この内容で課題を作成しますか？") {
 					fmt.Println("キャンセルしました")
@@ -191,7 +261,14 @@ func newCreateCmd() *cobra.Command {
 				}
 			}
 
-			issue, err := client.CreateIssue(opts)
+			if len(attach) > 0 {
+				opts.AttachmentIDs, err = cmdutil.UploadFiles(ctx, client, attach)
+				if err != nil {
+					return err
+				}
+			}
+
+			issue, err := client.CreateIssue(ctx, opts)
 			if err != nil {
 				return err
 			}
@@ -202,13 +279,45 @@ func newCreateCmd() *cobra.Command {
 	}
 
 	cmd.Flags().StringVarP(&summary, "summary", "s", "", "タイトル")
-	cmd.Flags().StringVarP(&typeName, "type", "t", "", "課題種別名")
-	cmd.Flags().StringVar(&priority, "priority", "", "優先度名")
+	cmd.Flags().StringVarP(&typeName, "type", "t", "", "課題種別名（省略時は .bl.yaml の issue_type）")
+	cmd.Flags().StringVar(&priority, "priority", "", "優先度名（省略時は .bl.yaml の priority）")
 	cmd.Flags().StringVarP(&assignee, "assignee", "a", "", "担当者名")
 	cmd.Flags().StringVarP(&description, "description", "d", "", "説明")
 	cmd.Flags().StringVar(&dueDate, "due-date", "", "期日（yyyy-MM-dd）")
 	cmd.Flags().StringVarP(&milestone, "milestone", "m", "", "マイルストーン名")
 	cmd.Flags().StringVarP(&project, "project", "p", "", "プロジェクトキー")
+	cmd.Flags().StringSliceVar(&attach, "attach", nil, "添付するファイル（複数指定可）")
+	cmd.Flags().StringArrayVarP(&fields, "field", "f", nil, "カスタム属性（名前=値、複数指定可）")
+	cmd.Flags().StringVar(&parent, "parent", "", "親課題の課題キー（子課題として作成）")
+	cmd.Flags().StringVar(&estimate, "estimate", "", "予定時間（例: 8h, 1.5, 90m）")
 
 	return cmd
 }
+
+// findIssueTypeID returns the ID of the issue type named name in projectKey.
+func findIssueTypeID(ctx context.Context, client *api.Client, projectKey, name string) (int, error) {
+	issueTypes, err := client.GetIssueTypes(ctx, projectKey)
+	if err != nil {
+		return 0, err
+	}
+	for _, t := range issueTypes {
+		if t.Name == name {
+			return t.ID, nil
+		}
+	}
+	return 0, fmt.Errorf("課題種別 '%s' が見つかりません", name)
+}
+
+// findPriorityID returns the ID of the priority named name.
+func findPriorityID(ctx context.Context, client *api.Client, name string) (int, error) {
+	priorities, err := client.GetPriorities(ctx)
+	if err != nil {
+		return 0, err
+	}
+	for _, p := range priorities {
+		if p.Name == name {
+			return p.ID, nil
+		}
+	}
+	return 0, fmt.Errorf("優先度 '%s' が見つかりません", name)
+}
@@ -0,0 +1,96 @@
+package issue
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/KimMaru10/bl-cli/internal/api"
+	"github.com/KimMaru10/bl-cli/internal/cmdutil"
+	"github.com/KimMaru10/bl-cli/internal/tui"
+)
+
+// promptCustomField asks for the value of a custom field with a prompt
+// suited to its type. current is the value on the issue, if any. It
+// returns the parameter values to set and false if the user cancelled.
+func promptCustomField(def api.CustomField, current *api.CustomFieldValue) ([]string, bool) {
+	label := def.Name
+	if def.Required {
+		label += " (必須)"
+	}
+
+	if !def.IsList() {
+		placeholder := ""
+		switch def.TypeID {
+		case api.CustomFieldNumber:
+			placeholder = "数値"
+		case api.CustomFieldDate:
+			placeholder = "yyyy-MM-dd"
+		}
+		prompt := label + ": "
+		if current != nil && current.String() != "" {
+			prompt = fmt.Sprintf("%s (現在: %s): ", label, current.String())
+		}
+		for {
+			val, ok := tui.Input(prompt, placeholder)
+			if !ok {
+				return nil, false
+			}
+			values, err := cmdutil.CustomFieldValues(def, val)
+			if err == nil {
+				return values, true
+			}
+			fmt.Println(warningStyle.Render(err.Error()))
+		}
+	}
+
+	var checked []int
+	if current != nil {
+		for _, item := range current.Items() {
+			checked = append(checked, item.ID)
+		}
+	}
+
+	items := make([]tui.SelectItem, 0, len(def.Items)+1)
+	if def.IsMultiple() {
+		for _, item := range def.Items {
+			items = append(items, tui.SelectItem{ID: item.ID, Label: item.Name})
+		}
+		selected, ok := tui.MultiSelect(label+"を選択", items, checked)
+		if !ok {
+			return nil, false
+		}
+		values := make([]string, len(selected))
+		for i, item := range selected {
+			values[i] = strconv.Itoa(item.ID)
+		}
+		return values, true
+	}
+
+	// Item IDs start at 1, so 0 is free for "unset"
+	items = append(items, tui.SelectItem{ID: 0, Label: "未設定"})
+	for _, item := range def.Items {
+		name := item.Name
+		if len(checked) > 0 && checked[0] == item.ID {
+			name += " (現在)"
+		}
+		items = append(items, tui.SelectItem{ID: item.ID, Label: name})
+	}
+	sel := tui.Select(label+"を選択", items)
+	if sel == nil {
+		return nil, false
+	}
+	if sel.ID == 0 {
+		return nil, true
+	}
+	return []string{strconv.Itoa(sel.ID)}, true
+}
+
+// findCustomFieldValue returns the value of field id on issue, or nil.
+func findCustomFieldValue(issue *api.Issue, id int) *api.CustomFieldValue {
+	for i, v := range issue.CustomFields {
+		if v.ID == id {
+			return &issue.CustomFields[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,134 @@
+package issue
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/KimMaru10/bl-cli/internal/cmdutil"
+	"github.com/KimMaru10/bl-cli/internal/tui"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/cobra"
+)
+
+var warningStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("1")).Bold(true)
+
+func newDeleteCmd() *cobra.Command {
+	var yes bool
+
+	cmd := &cobra.Command{
+		Use:   "delete <issueKey>",
+		Short: "課題を削除する",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			_, client, err := cmdutil.LoadConfigAndClient()
+			if err != nil {
+				return err
+			}
+
+			issue, err := client.GetIssue(ctx, args[0])
+			if err != nil {
+				return err
+			}
+
+			if !yes {
+				if !tui.Interactive() {
+					return errConfirmRequired
+				}
+				fmt.Println(titleStyle.Render(issue.IssueKey + " " + issue.Summary))
+				fmt.Println(warningStyle.Render("この課題を削除します。削除した課題は元に戻せません。"))
+				if !confirmByTyping(issue.IssueKey) {
+					fmt.Println("キャンセルしました")
+					return nil
+				}
+			}
+
+			if _, err := client.DeleteIssue(ctx, issue.IssueKey); err != nil {
+				return err
+			}
+
+			fmt.Println(successStyle.Render("✔ " + issue.IssueKey + " を削除しました"))
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "確認せずに削除する")
+
+	return cmd
+}
+
+func newCommentDeleteCmd() *cobra.Command {
+	var yes bool
+
+	cmd := &cobra.Command{
+		Use:   "delete <issueKey> <commentID>",
+		Short: "コメントを削除する",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			issueKey := args[0]
+			commentID, err := strconv.Atoi(args[1])
+			if err != nil {
+				return fmt.Errorf("コメント ID は数値で指定してください: %s", args[1])
+			}
+
+			_, client, err := cmdutil.LoadConfigAndClient()
+			if err != nil {
+				return err
+			}
+
+			if !yes {
+				if !tui.Interactive() {
+					return errConfirmRequired
+				}
+				issue, err := client.GetIssue(ctx, issueKey)
+				if err != nil {
+					return err
+				}
+				comment, err := client.GetComment(ctx, issueKey, commentID)
+				if err != nil {
+					return err
+				}
+
+				fmt.Println(titleStyle.Render(issue.IssueKey + " " + issue.Summary))
+				userName := ""
+				if comment.CreatedUser != nil {
+					userName = comment.CreatedUser.Name
+				}
+				fmt.Println(commentHeaderStyle.Render(userName + " — " + comment.Created))
+				fmt.Println(strings.TrimSpace(comment.Content))
+				fmt.Println()
+				fmt.Println(warningStyle.Render("このコメントを削除します。削除したコメントは元に戻せません。"))
+				if !confirmByTyping(issue.IssueKey) {
+					fmt.Println("キャンセルしました")
+					return nil
+				}
+			}
+
+			if _, err := client.DeleteComment(ctx, issueKey, commentID); err != nil {
+				return err
+			}
+
+			fmt.Println(successStyle.Render(fmt.Sprintf("✔ %s のコメント %d を削除しました", issueKey, commentID)))
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "確認せずに削除する")
+
+	return cmd
+}
+
+// errConfirmRequired is returned when a deletion needs confirmation but
+// there is no terminal to ask on.
+var errConfirmRequired = errors.New("確認できないため中止しました。端末から実行するか --yes を指定してください")
+
+// confirmByTyping asks the user to type want and reports whether they did.
+func confirmByTyping(want string) bool {
+	got, ok := tui.Input("確認のため "+want+" と入力してください: ", "")
+	return ok && strings.TrimSpace(got) == want
+}
@@ -0,0 +1,126 @@
+package issue
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/KimMaru10/bl-cli/internal/api"
+	"github.com/KimMaru10/bl-cli/internal/cmdutil"
+	"github.com/KimMaru10/bl-cli/internal/git"
+	"github.com/spf13/cobra"
+)
+
+// inProgressStatus is the status an issue is moved to by --start.
+const inProgressStatus = "処理中"
+
+func newDevelopCmd() *cobra.Command {
+	var (
+		tmpl      string
+		base      string
+		start     bool
+		printOnly bool
+	)
+
+	cmd := &cobra.Command{
+		Use:     "develop <issueKey>",
+		Aliases: []string{"branch"},
+		Short:   "課題の作業ブランチを作成する",
+		Long: `課題のブランチを作成してチェックアウトします。ブランチが既にあれば切り替えます。
+
+ブランチ名は --template、.bl.yaml の branch_template、既定の
+"` + git.DefaultBranchTemplate + `" の順に決まります。
+テンプレートでは .IssueKey .ProjectKey .Summary .IssueType .ID と、
+slug（英小文字とハイフンに変換し、カナはローマ字化）、lower、upper が使えます。`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			cfg, client, err := cmdutil.LoadConfigAndClient()
+			if err != nil {
+				return err
+			}
+
+			issue, err := client.GetIssue(ctx, args[0])
+			if err != nil {
+				return err
+			}
+
+			if tmpl == "" {
+				tmpl = cfg.Local().BranchTemplate
+			}
+			if tmpl == "" {
+				tmpl = git.DefaultBranchTemplate
+			}
+
+			projectKey, _, _ := strings.Cut(issue.IssueKey, "-")
+			data := git.BranchData{
+				IssueKey:   issue.IssueKey,
+				ProjectKey: projectKey,
+				Summary:    issue.Summary,
+				ID:         issue.ID,
+			}
+			if issue.IssueType != nil {
+				data.IssueType = issue.IssueType.Name
+			}
+			branch, err := git.BranchName(tmpl, data)
+			if err != nil {
+				return err
+			}
+
+			if printOnly {
+				fmt.Println(branch)
+				return nil
+			}
+
+			if git.BranchExists(branch) {
+				if err := git.Checkout(branch); err != nil {
+					return fmt.Errorf("ブランチの切り替えに失敗しました: %w", err)
+				}
+				fmt.Println(successStyle.Render("✔ 既存のブランチ " + branch + " に切り替えました"))
+			} else {
+				if err := git.CreateBranch(branch, base); err != nil {
+					return fmt.Errorf("ブランチの作成に失敗しました: %w", err)
+				}
+				fmt.Println(successStyle.Render("✔ ブランチ " + branch + " を作成しました"))
+			}
+
+			if !start {
+				return nil
+			}
+
+			opts := &api.UpdateIssueOptions{}
+			statuses, err := client.GetStatuses(ctx, data.ProjectKey)
+			if err != nil {
+				return err
+			}
+			for _, s := range statuses {
+				if s.Name == inProgressStatus {
+					opts.StatusID = intPtr(s.ID)
+					break
+				}
+			}
+			if opts.StatusID == nil {
+				return fmt.Errorf("ステータス '%s' が見つかりません", inProgressStatus)
+			}
+
+			me, err := client.GetMyself(ctx)
+			if err != nil {
+				return err
+			}
+			opts.AssigneeID = intPtr(me.ID)
+
+			if _, err := client.UpdateIssue(ctx, issue.IssueKey, opts); err != nil {
+				return err
+			}
+			fmt.Println(successStyle.Render(fmt.Sprintf("✔ %s を%sにして %s に割り当てました", issue.IssueKey, inProgressStatus, me.Name)))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&tmpl, "template", "", "ブランチ名のテンプレート")
+	cmd.Flags().StringVar(&base, "base", "", "分岐元のブランチ（既定: 現在の HEAD）")
+	cmd.Flags().BoolVar(&start, "start", false, "課題を"+inProgressStatus+"にして自分に割り当てる")
+	cmd.Flags().BoolVar(&printOnly, "print", false, "ブランチを作成せず名前だけ表示する")
+
+	return cmd
+}
@@ -15,12 +15,18 @@ func strPtr(v string) *string { return &v }
 
 func newEditCmd() *cobra.Command {
 	var (
-		status    string
-		assignee  string
-		dueDate   string
-		priority  string
-		milestone string
-		comment   string
+		status     string
+		resolution string
+		assignee   string
+		dueDate    string
+		priority   string
+		milestone  string
+		comment    string
+		notify     []string
+		attach     []string
+		fields     []string
+		parent     string
+		estimate   string
 	)
 
 	cmd := &cobra.Command{
@@ -28,36 +34,40 @@ func newEditCmd() *cobra.Command {
 		Short: "課題を更新する",
 		Args:  cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
 			cfg, client, err := cmdutil.LoadConfigAndClient()
 			if err != nil {
 				return err
 			}
 
-			issueKey, err := resolveIssueKey(args)
+			issueKey, err := cmdutil.ResolveIssueKey(cfg, args)
 			if err != nil {
 				return err
 			}
 
 			// Get current issue to determine project key
-			currentIssue, err := client.GetIssue(issueKey)
+			currentIssue, err := client.GetIssue(ctx, issueKey)
 			if err != nil {
 				return err
 			}
 
-			// Extract project key from issue key (e.g. "TEST-1" -> "TEST")
-			projectKey := issueKey[:strings.Index(issueKey, "-")]
-			_ = cfg
+			// Take the project key from the fetched issue, since issueKey
+			// may be a numeric ID
+			projectKey, _, _ := strings.Cut(currentIssue.IssueKey, "-")
 
-			hasFlags := cmd.Flags().Changed("status") || cmd.Flags().Changed("assignee") ||
+			hasFlags := cmd.Flags().Changed("status") || cmd.Flags().Changed("resolution") || cmd.Flags().Changed("assignee") ||
 				cmd.Flags().Changed("due-date") || cmd.Flags().Changed("priority") ||
-				cmd.Flags().Changed("milestone") || cmd.Flags().Changed("comment")
+				cmd.Flags().Changed("milestone") || cmd.Flags().Changed("comment") || cmd.Flags().Changed("notify") ||
+				cmd.Flags().Changed("attach") || cmd.Flags().Changed("field") ||
+				cmd.Flags().Changed("parent") || cmd.Flags().Changed("estimate")
 
 			opts := &api.UpdateIssueOptions{}
 
 			if hasFlags {
 				// Flag mode
 				if status != "" {
-					statuses, err := client.GetStatuses(projectKey)
+					statuses, err := client.GetStatuses(ctx, projectKey)
 					if err != nil {
 						return err
 					}
@@ -72,8 +82,16 @@ func newEditCmd() *cobra.Command {
 					}
 				}
 
+				if resolution != "" {
+					id, err := findResolutionID(ctx, client, resolution)
+					if err != nil {
+						return err
+					}
+					opts.ResolutionID = intPtr(id)
+				}
+
 				if assignee != "" {
-					users, err := client.GetProjectUsers(projectKey)
+					users, err := client.GetProjectUsers(ctx, projectKey)
 					if err != nil {
 						return err
 					}
@@ -90,7 +108,7 @@ func newEditCmd() *cobra.Command {
 				}
 
 				if priority != "" {
-					priorities, err := client.GetPriorities()
+					priorities, err := client.GetPriorities(ctx)
 					if err != nil {
 						return err
 					}
@@ -106,7 +124,7 @@ func newEditCmd() *cobra.Command {
 				}
 
 				if milestone != "" {
-					milestones, err := client.GetMilestones(projectKey)
+					milestones, err := client.GetMilestones(ctx, projectKey)
 					if err != nil {
 						return err
 					}
@@ -121,6 +139,56 @@ func newEditCmd() *cobra.Command {
 				if comment != "" {
 					opts.Comment = strPtr(comment)
 				}
+
+				if len(notify) > 0 || strings.Contains(comment, "@") {
+					ids, err := cmdutil.NotifiedUserIDs(ctx, client, projectKey, notify, comment)
+					if err != nil {
+						return err
+					}
+					opts.NotifiedUserIDs = ids
+				}
+
+				if estimate != "" {
+					hours, err := cmdutil.ParseHours(estimate)
+					if err != nil {
+						return err
+					}
+					opts.EstimatedHours = &hours
+				}
+
+				if cmd.Flags().Changed("parent") {
+					if parent == "" {
+						opts.ParentIssueID = intPtr(0)
+					} else {
+						parentIssue, err := client.GetIssue(ctx, parent)
+						if err != nil {
+							return err
+						}
+						opts.ParentIssueID = intPtr(parentIssue.ID)
+					}
+				}
+
+				if len(fields) > 0 {
+					fieldArgs, err := cmdutil.ParseFieldArgs(fields)
+					if err != nil {
+						return err
+					}
+					defs, err := client.GetCustomFields(ctx, projectKey)
+					if err != nil {
+						return err
+					}
+					opts.CustomFields, err = cmdutil.CustomFieldParams(defs, fieldArgs)
+					if err != nil {
+						return err
+					}
+				}
+
+				if len(attach) > 0 {
+					opts.AttachmentIDs, err = cmdutil.UploadFiles(ctx, client, attach)
+					if err != nil {
+						return err
+					}
+				}
 			} else {
 				// Interactive mode
 				editItems := []tui.SelectItem{
@@ -129,6 +197,7 @@ func newEditCmd() *cobra.Command {
 					{ID: 3, Label: "期日を変更"},
 					{ID: 4, Label: "優先度を変更"},
 					{ID: 5, Label: "マイルストーンを変更"},
+					{ID: 6, Label: "カスタム属性を変更"},
 				}
 
 				selected := tui.Select("編集項目を選択", editItems)
@@ -138,7 +207,7 @@ func newEditCmd() *cobra.Command {
 
 				switch selected.ID {
 				case 1: // Status
-					statuses, err := client.GetStatuses(projectKey)
+					statuses, err := client.GetStatuses(ctx, projectKey)
 					if err != nil {
 						return err
 					}
@@ -157,7 +226,7 @@ func newEditCmd() *cobra.Command {
 					opts.StatusID = intPtr(sel.ID)
 
 				case 2: // Assignee
-					users, err := client.GetProjectUsers(projectKey)
+					users, err := client.GetProjectUsers(ctx, projectKey)
 					if err != nil {
 						return err
 					}
@@ -187,7 +256,7 @@ func newEditCmd() *cobra.Command {
 					opts.DueDate = strPtr(val)
 
 				case 4: // Priority
-					priorities, err := client.GetPriorities()
+					priorities, err := client.GetPriorities(ctx)
 					if err != nil {
 						return err
 					}
@@ -206,7 +275,7 @@ func newEditCmd() *cobra.Command {
 					opts.PriorityID = intPtr(sel.ID)
 
 				case 5: // Milestone
-					milestones, err := client.GetMilestones(projectKey)
+					milestones, err := client.GetMilestones(ctx, projectKey)
 					if err != nil {
 						return err
 					}
@@ -223,6 +292,41 @@ func newEditCmd() *cobra.Command {
 					} else {
 						opts.MilestoneIDs = []int{}
 					}
+
+				case 6: // Custom field
+					defs, err := client.GetCustomFields(ctx, projectKey)
+					if err != nil {
+						return err
+					}
+					var items []tui.SelectItem
+					for _, def := range defs {
+						if currentIssue.IssueType != nil && !def.AppliesTo(currentIssue.IssueType.ID) {
+							continue
+						}
+						label := def.Name
+						if v := findCustomFieldValue(currentIssue, def.ID); v != nil && v.String() != "" {
+							label += " (現在: " + v.String() + ")"
+						}
+						items = append(items, tui.SelectItem{ID: def.ID, Label: label})
+					}
+					if len(items) == 0 {
+						fmt.Println("この課題に設定できるカスタム属性はありません")
+						return nil
+					}
+					sel := tui.Select("カスタム属性を選択", items)
+					if sel == nil {
+						return nil
+					}
+					for _, def := range defs {
+						if def.ID != sel.ID {
+							continue
+						}
+						values, ok := promptCustomField(def, findCustomFieldValue(currentIssue, def.ID))
+						if !ok {
+							return nil
+						}
+						opts.CustomFields = api.CustomFieldParams{def.ID: values}
+					}
 				}
 
 				if !tui.Confirm("この内容で更新しますか？") {
@@ -231,7 +335,7 @@ func newEditCmd() *cobra.Command {
 				}
 			}
 
-			_, err = client.UpdateIssue(issueKey, opts)
+			_, err = client.UpdateIssue(ctx, issueKey, opts)
 			if err != nil {
 				return err
 			}
@@ -242,11 +346,17 @@ func newEditCmd() *cobra.Command {
 	}
 
 	cmd.Flags().StringVar(&status, "status", "", "ステータス名")
+	cmd.Flags().StringVarP(&resolution, "resolution", "r", "", "完了理由名")
 	cmd.Flags().StringVarP(&assignee, "assignee", "a", "", "担当者名")
 	cmd.Flags().StringVar(&dueDate, "due-date", "", "期日（yyyy-MM-dd）")
 	cmd.Flags().StringVar(&priority, "priority", "", "優先度名")
 	cmd.Flags().StringVarP(&milestone, "milestone", "m", "", "マイルストーン名")
-	cmd.Flags().StringVar(&comment, "comment", "", "更新時コメント")
+	cmd.Flags().StringVar(&comment, "comment", "", "更新時コメント（@ユーザーID でメンション）")
+	cmd.Flags().StringSliceVar(&notify, "notify", nil, "通知するユーザー名またはユーザーID（カンマ区切り）")
+	cmd.Flags().StringSliceVar(&attach, "attach", nil, "添付するファイル（複数指定可）")
+	cmd.Flags().StringArrayVarP(&fields, "field", "f", nil, "カスタム属性（名前=値、空の値で解除、複数指定可）")
+	cmd.Flags().StringVar(&parent, "parent", "", "親課題の課題キー（空文字で親課題を解除）")
+	cmd.Flags().StringVar(&estimate, "estimate", "", "予定時間（例: 8h, 1.5, 90m）")
 
 	return cmd
 }
@@ -0,0 +1,40 @@
+package issue
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// editInEditor writes text to a temporary file named name, opens it in
+// $EDITOR (vim by default) and returns the saved contents. $EDITOR may
+// include arguments, e.g. "code --wait".
+func editInEditor(name, text string) (string, error) {
+	editor := strings.TrimSpace(os.Getenv("EDITOR"))
+	if editor == "" {
+		editor = "vim"
+	}
+
+	tmpFile := filepath.Join(os.TempDir(), name)
+	if err := os.WriteFile(tmpFile, []byte(text), 0644); err != nil {
+		return "", fmt.Errorf("一時ファイルの作成に失敗しました: %w", err)
+	}
+	defer os.Remove(tmpFile)
+
+	args := append(strings.Fields(editor), tmpFile)
+	editorCmd := exec.Command(args[0], args[1:]...)
+	editorCmd.Stdin = os.Stdin
+	editorCmd.Stdout = os.Stdout
+	editorCmd.Stderr = os.Stderr
+	if err := editorCmd.Run(); err != nil {
+		return "", fmt.Errorf("エディタの起動に失敗しました: %w", err)
+	}
+
+	data, err := os.ReadFile(tmpFile)
+	if err != nil {
+		return "", fmt.Errorf("一時ファイルの読み込みに失敗しました: %w", err)
+	}
+	return string(data), nil
+}
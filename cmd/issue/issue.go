@@ -9,11 +9,19 @@ func NewIssueCmd() *cobra.Command {
 		Short: "課題の管理",
 	}
 
-	cmd.AddCommand(newListCmd())
+	cmd.AddCommand(NewListCmd())
 	cmd.AddCommand(newViewCmd())
 	cmd.AddCommand(newCreateCmd())
 	cmd.AddCommand(newEditCmd())
 	cmd.AddCommand(newCommentCmd())
+	cmd.AddCommand(newCloseCmd())
+	cmd.AddCommand(newReopenCmd())
+	cmd.AddCommand(newResolveCmd())
+	cmd.AddCommand(newDevelopCmd())
+	cmd.AddCommand(newDeleteCmd())
+	cmd.AddCommand(newAttachmentCmd())
+	cmd.AddCommand(newTreeCmd())
+	cmd.AddCommand(newLogTimeCmd())
 
 	return cmd
 }
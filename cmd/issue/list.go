@@ -2,11 +2,15 @@ package issue
 
 import (
 	"fmt"
+	"os"
+	"slices"
 	"strings"
 
 	"github.com/KimMaru10/bl-cli/internal/api"
 	"github.com/KimMaru10/bl-cli/internal/browser"
 	"github.com/KimMaru10/bl-cli/internal/cmdutil"
+	"github.com/KimMaru10/bl-cli/internal/config"
+	"github.com/KimMaru10/bl-cli/internal/search"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/spf13/cobra"
 )
@@ -26,20 +30,97 @@ func statusColor(name string) lipgloss.Style {
 	}
 }
 
-func newListCmd() *cobra.Command {
+// listFilterFlags are the bl issue list flags that map to a search query
+// key. Their values take the same form as in a query, e.g. "未対応,処理中"
+// or "<2026-11-01".
+var listFilterFlags = []struct {
+	name, shorthand, key, usage string
+}{
+	{"assignee", "a", "assignee", "担当者名（@me で自分）"},
+	{"status", "s", "status", "ステータス名"},
+	{"milestone", "m", "milestone", "マイルストーン名"},
+	{"type", "", "type", "課題種別名"},
+	{"category", "", "category", "カテゴリー名"},
+	{"priority", "", "priority", "優先度名"},
+	{"resolution", "", "resolution", "完了理由"},
+	{"author", "", "author", "登録者名（@me で自分）"},
+	{"parent", "", "parent", "指定した課題の子課題のみ表示する"},
+	{"created", "", "created", "登録日（例: >=2026-10-01, 2026-10-01..2026-10-31）"},
+	{"updated", "", "updated", "更新日"},
+	{"start", "", "start", "開始日"},
+	{"due", "", "due", "期限日（例: <today）"},
+	{"keyword", "k", "keyword", "キーワード"},
+}
+
+// Defaults of the bl issue list --sort and --order flags.
+const (
+	defaultListSort  = "updated"
+	defaultListOrder = "desc"
+)
+
+// findView returns the view named by an @NAME argument, along with the
+// remaining arguments. It returns an empty view if there is none.
+func findView(cfg *config.Config, args []string) (config.View, []string, error) {
+	var (
+		view  config.View
+		found string
+		rest  []string
+	)
+	for _, arg := range args {
+		name, ok := strings.CutPrefix(arg, "@")
+		if !ok {
+			rest = append(rest, arg)
+			continue
+		}
+		if found != "" {
+			return view, nil, fmt.Errorf("ビューは 1 つだけ指定できます: @%s, @%s", found, name)
+		}
+		if view, ok = cfg.View(name); !ok {
+			return view, nil, fmt.Errorf("ビュー '%s' が見つかりません（bl view list で確認できます）", name)
+		}
+		found = name
+	}
+	return view, rest, nil
+}
+
+// NewListCmd returns the bl issue list command. It is exported so that
+// bl view run can share its flags.
+func NewListCmd() *cobra.Command {
 	var (
-		assignee  string
-		status    string
-		milestone string
-		project   string
-		count     int
-		web       bool
+		project  string
+		limit    int
+		all      bool
+		web      bool
+		fields   []string
+		parents  bool
+		attached bool
+		sortKey  string
+		order    string
+		columns  []string
+		save     string
+		exporter *cmdutil.Exporter
 	)
 
 	cmd := &cobra.Command{
-		Use:   "list",
+		Use:   "list [@view] [query]",
 		Short: "課題一覧を表示する",
+		Long: `課題一覧を表示します。
+
+絞り込みの条件は検索クエリまたはフラグで指定します。両方を指定すると
+すべての条件を満たす課題を表示します。
+
+--save NAME で条件・並び順・表示列をビューとして保存し、@NAME で
+呼び出せます。@NAME に続けて指定した条件やフラグはビューに追加されます。
+
+` + search.Syntax,
+		Example: `  bl issue list status:処理中 assignee:@me
+  bl issue list "due:<2026-11-01 type:バグ is:open"
+  bl issue list --type バグ --priority 高 --updated ">=2026-10-01"
+  bl issue list assignee:@me type:バグ is:open --save my-bugs
+  bl issue list @my-bugs priority:高`,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
 			cfg, client, err := cmdutil.LoadConfigAndClient()
 			if err != nil {
 				return err
@@ -47,6 +128,38 @@ func newListCmd() *cobra.Command {
 
 			space := cfg.Current()
 
+			if save != "" {
+				if err := config.ValidateViewName(save); err != nil {
+					return err
+				}
+			}
+
+			view, args, err := findView(cfg, args)
+			if err != nil {
+				return err
+			}
+			if !cmd.Flags().Changed("project") {
+				project = view.Project
+			}
+			if !cmd.Flags().Changed("sort") && view.Sort != "" {
+				sortKey = view.Sort
+			}
+			if !cmd.Flags().Changed("order") && view.Order != "" {
+				order = view.Order
+			}
+			if !cmd.Flags().Changed("columns") && len(view.Columns) > 0 {
+				columns = view.Columns
+			}
+			fields = append(slices.Clone(view.Fields), fields...)
+
+			if order != "asc" && order != "desc" {
+				return fmt.Errorf("--order には asc または desc を指定してください: %s", order)
+			}
+			cols, err := listColumnsByName(columns)
+			if err != nil {
+				return err
+			}
+
 			projectKey := project
 			if projectKey == "" {
 				projectKey = space.DefaultProject
@@ -60,109 +173,125 @@ func newListCmd() *cobra.Command {
 				return browser.Open(url)
 			}
 
-			proj, err := client.GetProject(projectKey)
+			proj, err := client.GetProject(ctx, projectKey)
 			if err != nil {
 				return err
 			}
 
 			opts := &api.GetIssuesOptions{
 				ProjectIDs: []int{proj.ID},
-				Count:      count,
-				Sort:       "updated",
-				Order:      "desc",
+				Sort:       sortKey,
+				Order:      order,
 			}
 
-			if assignee == "@me" {
-				me, err := client.GetMyself()
+			q, err := search.Parse(strings.TrimSpace(view.Query + " " + strings.Join(args, " ")))
+			if err != nil {
+				return err
+			}
+			for _, f := range listFilterFlags {
+				if cmd.Flags().Changed(f.name) {
+					if err := q.Set(f.key, cmd.Flag(f.name).Value.String()); err != nil {
+						return err
+					}
+				}
+			}
+			if parents {
+				q.Is = append(q.Is, "parent")
+			}
+			if attached {
+				q.Attachment = true
+			}
+			if err := q.Resolve(ctx, client, projectKey, space.Projects[projectKey].CloseStatus, opts); err != nil {
+				return err
+			}
+
+			if len(fields) > 0 {
+				fieldArgs, err := cmdutil.ParseFieldArgs(fields)
 				if err != nil {
 					return err
 				}
-				opts.AssigneeIDs = []int{me.ID}
-			} else if assignee != "" {
-				users, err := client.GetProjectUsers(projectKey)
+				defs, err := client.GetCustomFields(ctx, projectKey)
 				if err != nil {
 					return err
 				}
-				for _, u := range users {
-					if u.Name == assignee {
-						opts.AssigneeIDs = []int{u.ID}
-						break
-					}
-				}
-			}
-
-			if status != "" {
-				statuses, err := client.GetStatuses(projectKey)
+				opts.CustomFields, err = cmdutil.CustomFieldFilters(defs, fieldArgs)
 				if err != nil {
 					return err
 				}
-				for _, s := range statuses {
-					if s.Name == status {
-						opts.StatusIDs = []int{s.ID}
-						break
-					}
-				}
 			}
 
-			if milestone != "" {
-				milestones, err := client.GetMilestones(projectKey)
-				if err != nil {
-					return err
+			if save != "" {
+				saved := config.View{
+					Project: project,
+					Query:   q.String(),
+					Fields:  fields,
+					Columns: columns,
 				}
-				for _, m := range milestones {
-					if m.Name == milestone {
-						opts.MilestoneIDs = []int{m.ID}
-						break
-					}
+				if sortKey != defaultListSort {
+					saved.Sort = sortKey
 				}
+				if order != defaultListOrder {
+					saved.Order = order
+				}
+				if err := cfg.SaveView(save, saved); err != nil {
+					return err
+				}
+				fmt.Fprintln(os.Stderr, successStyle.Render("✔ ビュー '"+save+"' を保存しました（bl issue list @"+save+" で表示できます）"))
 			}
 
-			issues, err := client.GetIssues(opts)
+			if all {
+				limit = 0
+			}
+			issues, err := client.ListIssues(ctx, opts, limit)
 			if err != nil {
 				return err
 			}
 
+			if exporter.Enabled() {
+				return exporter.Write(os.Stdout, issues)
+			}
+
 			if len(issues) == 0 {
 				fmt.Println("該当する課題はありません")
 				return nil
 			}
 
 			headerStyle := lipgloss.NewStyle().Bold(true)
-			fmt.Printf("%s\t%s\t%s\t%s\n",
-				headerStyle.Render("KEY"),
-				headerStyle.Render("STATUS"),
-				headerStyle.Render("ASSIGNEE"),
-				headerStyle.Render("TITLE"),
-			)
+			header := make([]string, len(cols))
+			for i, c := range cols {
+				header[i] = headerStyle.Render(c.header)
+			}
+			fmt.Println(strings.Join(header, "\t"))
 
 			for _, issue := range issues {
-				statusName := ""
-				if issue.Status != nil {
-					statusName = statusColor(issue.Status.Name).Render(issue.Status.Name)
+				row := make([]string, len(cols))
+				for i, c := range cols {
+					row[i] = c.value(issue)
 				}
-
-				assigneeName := ""
-				if issue.Assignee != nil {
-					assigneeName = issue.Assignee.Name
-				}
-
-				fmt.Printf("%s\t%s\t%s\t%s\n",
-					issue.IssueKey,
-					statusName,
-					assigneeName,
-					issue.Summary,
-				)
+				fmt.Println(strings.Join(row, "\t"))
 			}
 			return nil
 		},
 	}
 
-	cmd.Flags().StringVarP(&assignee, "assignee", "a", "", "担当者名（@me で自分）")
-	cmd.Flags().StringVarP(&status, "status", "s", "", "ステータス名")
-	cmd.Flags().StringVarP(&milestone, "milestone", "m", "", "マイルストーン名")
+	for _, f := range listFilterFlags {
+		cmd.Flags().StringP(f.name, f.shorthand, "", f.usage)
+	}
 	cmd.Flags().StringVarP(&project, "project", "p", "", "プロジェクトキー")
-	cmd.Flags().IntVarP(&count, "count", "c", 20, "表示件数")
+	cmd.Flags().IntVarP(&limit, "limit", "L", 20, "表示件数（100 件を超える場合は自動でページング）")
+	cmd.Flags().IntVarP(&limit, "count", "c", 20, "表示件数")
+	_ = cmd.Flags().MarkDeprecated("count", "--limit を使用してください")
+	cmd.Flags().BoolVar(&all, "all", false, "該当する課題をすべて表示する")
 	cmd.Flags().BoolVarP(&web, "web", "w", false, "ブラウザで開く")
+	cmd.Flags().BoolVar(&parents, "has-children", false, "子課題を持つ課題のみ表示する")
+	cmd.Flags().BoolVar(&attached, "has-attachment", false, "添付ファイルのある課題のみ表示する")
+	cmd.MarkFlagsMutuallyExclusive("parent", "has-children")
+	cmd.Flags().StringArrayVarP(&fields, "field", "f", nil, "カスタム属性で絞り込む（名前=値、数値・日付は 下限..上限 も可）")
+	cmd.Flags().StringVar(&sortKey, "sort", defaultListSort, "並び替えの項目（created, updated, dueDate, priority, status など）")
+	cmd.Flags().StringVar(&order, "order", defaultListOrder, "並び順（asc, desc）")
+	cmd.Flags().StringSliceVar(&columns, "columns", nil, "表示する列（"+strings.Join(listColumnNames(), ", ")+"）")
+	cmd.Flags().StringVar(&save, "save", "", "条件をビューとして保存する")
+	exporter = cmdutil.AddJSONFlags(cmd, cmdutil.StructFields(api.Issue{}))
 
 	return cmd
 }
@@ -0,0 +1,71 @@
+package issue
+
+import (
+	"fmt"
+
+	"github.com/KimMaru10/bl-cli/internal/api"
+	"github.com/KimMaru10/bl-cli/internal/cmdutil"
+	"github.com/spf13/cobra"
+)
+
+func newLogTimeCmd() *cobra.Command {
+	var comment string
+
+	cmd := &cobra.Command{
+		Use:   "log-time [issueKey] <duration>",
+		Short: "課題の実績時間を記録する",
+		Long: `作業時間を課題の実績時間に加算します。
+
+時間は 1.5h・90m・1h30m・1.5 のように指定します。課題キーを省略すると
+ブランチ名などから推測します。`,
+		Example: `  bl issue log-time PROJ-123 1.5h --comment "レビュー指摘の修正"
+  bl issue log-time 30m`,
+		Args: cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			hours, err := cmdutil.ParseHours(args[len(args)-1])
+			if err != nil {
+				return err
+			}
+			if hours == 0 {
+				return fmt.Errorf("0 より大きい時間を指定してください")
+			}
+
+			cfg, client, err := cmdutil.LoadConfigAndClient()
+			if err != nil {
+				return err
+			}
+
+			issueKey, err := cmdutil.ResolveIssueKey(cfg, args[:len(args)-1])
+			if err != nil {
+				return err
+			}
+
+			updated, err := cmdutil.LogTime(ctx, client, issueKey, hours, comment)
+			if err != nil {
+				return err
+			}
+
+			fmt.Println(successStyle.Render(fmt.Sprintf("✔ %s に %s を記録しました（%s）", issueKey, cmdutil.FormatHours(hours), hoursSummary(updated))))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&comment, "comment", "", "更新時コメント")
+
+	return cmd
+}
+
+// hoursSummary formats the estimated and actual hours of an issue, e.g.
+// "予定 8h / 実績 3.5h".
+func hoursSummary(issue *api.Issue) string {
+	estimated, actual := "-", "-"
+	if issue.EstimatedHours != nil {
+		estimated = cmdutil.FormatHours(*issue.EstimatedHours)
+	}
+	if issue.ActualHours != nil {
+		actual = cmdutil.FormatHours(*issue.ActualHours)
+	}
+	return "予定 " + estimated + " / 実績 " + actual
+}
@@ -0,0 +1,165 @@
+package issue
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/KimMaru10/bl-cli/internal/api"
+	"github.com/KimMaru10/bl-cli/internal/cmdutil"
+	"github.com/KimMaru10/bl-cli/internal/config"
+	"github.com/spf13/cobra"
+)
+
+// statusChange describes one of bl issue close, reopen and resolve.
+type statusChange struct {
+	use   string
+	short string
+	// configured returns the status name set for the project, if any.
+	configured func(config.ProjectConfig) string
+	// defaultID is the built-in status to use otherwise.
+	defaultID int
+	// fallback picks a status by position when defaultID has been removed.
+	fallback func(statuses []api.Status) api.Status
+	// resolution reports whether --resolution is accepted.
+	resolution bool
+}
+
+func newCloseCmd() *cobra.Command {
+	return newStatusChangeCmd(statusChange{
+		use:        "close",
+		short:      "課題を完了にする",
+		configured: func(p config.ProjectConfig) string { return p.CloseStatus },
+		defaultID:  api.StatusIDClosed,
+		fallback:   func(s []api.Status) api.Status { return s[len(s)-1] },
+		resolution: true,
+	})
+}
+
+func newReopenCmd() *cobra.Command {
+	return newStatusChangeCmd(statusChange{
+		use:        "reopen",
+		short:      "課題を未対応に戻す",
+		configured: func(p config.ProjectConfig) string { return p.ReopenStatus },
+		defaultID:  api.StatusIDOpen,
+		fallback:   func(s []api.Status) api.Status { return s[0] },
+	})
+}
+
+func newResolveCmd() *cobra.Command {
+	return newStatusChangeCmd(statusChange{
+		use:        "resolve",
+		short:      "課題を処理済みにする",
+		configured: func(p config.ProjectConfig) string { return p.ResolveStatus },
+		defaultID:  api.StatusIDResolved,
+		fallback:   func(s []api.Status) api.Status { return s[max(len(s)-2, 0)] },
+		resolution: true,
+	})
+}
+
+func newStatusChangeCmd(sc statusChange) *cobra.Command {
+	var (
+		resolution string
+		comment    string
+	)
+
+	cmd := &cobra.Command{
+		Use:   sc.use + " [issueKey]",
+		Short: sc.short,
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			cfg, client, err := cmdutil.LoadConfigAndClient()
+			if err != nil {
+				return err
+			}
+
+			issueKey, err := cmdutil.ResolveIssueKey(cfg, args)
+			if err != nil {
+				return err
+			}
+			projectKey, err := cmdutil.IssueProjectKey(ctx, client, issueKey)
+			if err != nil {
+				return err
+			}
+
+			statuses, err := client.GetStatuses(ctx, projectKey)
+			if err != nil {
+				return err
+			}
+			if len(statuses) == 0 {
+				return fmt.Errorf("プロジェクト %s にステータスがありません", projectKey)
+			}
+			status, err := pickStatus(statuses, sc, cfg.Current().Projects[projectKey])
+			if err != nil {
+				return err
+			}
+
+			opts := &api.UpdateIssueOptions{StatusID: intPtr(status.ID)}
+			if resolution != "" {
+				id, err := findResolutionID(ctx, client, resolution)
+				if err != nil {
+					return err
+				}
+				opts.ResolutionID = intPtr(id)
+			}
+			if comment != "" {
+				opts.Comment = strPtr(comment)
+			}
+
+			if _, err := client.UpdateIssue(ctx, issueKey, opts); err != nil {
+				return err
+			}
+
+			msg := fmt.Sprintf("✔ %s のステータスを %s にしました", issueKey, status.Name)
+			if resolution != "" {
+				msg += "（完了理由: " + resolution + "）"
+			}
+			fmt.Println(successStyle.Render(msg))
+			return nil
+		},
+	}
+
+	if sc.resolution {
+		cmd.Flags().StringVarP(&resolution, "resolution", "r", "", "完了理由（対応済み, 対応しない, 重複, 無効, 再現しない など）")
+	}
+	cmd.Flags().StringVar(&comment, "comment", "", "更新時コメント")
+
+	return cmd
+}
+
+// pickStatus returns the status named in the project config, or else the
+// built-in status, or else the one chosen by position.
+func pickStatus(statuses []api.Status, sc statusChange, project config.ProjectConfig) (api.Status, error) {
+	if name := sc.configured(project); name != "" {
+		for _, s := range statuses {
+			if s.Name == name {
+				return s, nil
+			}
+		}
+		return api.Status{}, fmt.Errorf("設定されたステータス '%s' が見つかりません", name)
+	}
+	for _, s := range statuses {
+		if s.ID == sc.defaultID {
+			return s, nil
+		}
+	}
+	return sc.fallback(statuses), nil
+}
+
+// findResolutionID returns the ID of the resolution named name.
+func findResolutionID(ctx context.Context, client *api.Client, name string) (int, error) {
+	resolutions, err := client.GetResolutions(ctx)
+	if err != nil {
+		return 0, err
+	}
+	names := make([]string, len(resolutions))
+	for i, r := range resolutions {
+		if r.Name == name {
+			return r.ID, nil
+		}
+		names[i] = r.Name
+	}
+	return 0, fmt.Errorf("完了理由 '%s' が見つかりません（利用可能: %s）", name, strings.Join(names, ", "))
+}
@@ -0,0 +1,115 @@
+package issue
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/KimMaru10/bl-cli/internal/api"
+	"github.com/KimMaru10/bl-cli/internal/cmdutil"
+	"github.com/spf13/cobra"
+)
+
+func newTreeCmd() *cobra.Command {
+	var exporter *cmdutil.Exporter
+
+	cmd := &cobra.Command{
+		Use:   "tree [issueKey]",
+		Short: "親課題と子課題をツリー表示する",
+		Long: `親課題とその子課題をツリー形式で表示します。
+
+子課題を指定した場合は、その親課題から表示します。`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			cfg, client, err := cmdutil.LoadConfigAndClient()
+			if err != nil {
+				return err
+			}
+
+			issueKey, err := cmdutil.ResolveIssueKey(cfg, args)
+			if err != nil {
+				return err
+			}
+
+			parent, err := client.GetIssue(ctx, issueKey)
+			if err != nil {
+				return err
+			}
+			if parent.ParentIssueID != nil {
+				parent, err = client.GetIssue(ctx, strconv.Itoa(*parent.ParentIssueID))
+				if err != nil {
+					return err
+				}
+			}
+
+			children, err := client.GetChildIssues(ctx, parent)
+			if err != nil {
+				return err
+			}
+
+			if exporter.Enabled() {
+				return exporter.Write(os.Stdout, issueTree{Issue: *parent, Children: children})
+			}
+
+			projectKey, _, _ := strings.Cut(parent.IssueKey, "-")
+			closeStatus := cfg.Current().Projects[projectKey].CloseStatus
+
+			header := titleStyle.Render(parent.IssueKey+" "+parent.Summary) + " " + issueStatusLabel(parent)
+			if len(children) > 0 {
+				done := countClosed(children, closeStatus)
+				header += labelStyle.Render(fmt.Sprintf(" (%d/%d 完了)", done, len(children)))
+			}
+			fmt.Println(header)
+
+			if len(children) == 0 {
+				fmt.Println(labelStyle.Render("子課題はありません"))
+				return nil
+			}
+			for i, child := range children {
+				branch := "├── "
+				if i == len(children)-1 {
+					branch = "└── "
+				}
+				line := child.IssueKey + " " + issueStatusLabel(&child) + " " + child.Summary
+				if child.Assignee != nil {
+					line += labelStyle.Render(" @" + child.Assignee.Name)
+				}
+				fmt.Println(labelStyle.Render(branch) + line)
+			}
+			return nil
+		},
+	}
+
+	exporter = cmdutil.AddJSONFlags(cmd, cmdutil.StructFields(issueTree{}))
+
+	return cmd
+}
+
+// issueTree is the structured output of bl issue tree.
+type issueTree struct {
+	api.Issue
+	Children []api.Issue `json:"children"`
+}
+
+// issueStatusLabel renders the status of issue as a colored [status] label.
+func issueStatusLabel(issue *api.Issue) string {
+	if issue.Status == nil {
+		return ""
+	}
+	return statusColor(issue.Status.Name).Render("[" + issue.Status.Name + "]")
+}
+
+// countClosed returns how many issues are in the closed status: the one
+// named in the project config if set, or else the built-in 完了.
+func countClosed(issues []api.Issue, closeStatus string) int {
+	n := 0
+	for _, issue := range issues {
+		if issue.Status != nil && issue.Status.IsClosed(closeStatus) {
+			n++
+		}
+	}
+	return n
+}
@@ -2,11 +2,13 @@ package issue
 
 import (
 	"fmt"
+	"os"
+	"strconv"
 	"strings"
 
+	"github.com/KimMaru10/bl-cli/internal/api"
 	"github.com/KimMaru10/bl-cli/internal/browser"
 	"github.com/KimMaru10/bl-cli/internal/cmdutil"
-	"github.com/KimMaru10/bl-cli/internal/git"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/spf13/cobra"
 )
@@ -17,36 +19,25 @@ var (
 	urlStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color("4")).Underline(true)
 )
 
-// resolveIssueKey resolves an issue key from args or the current git branch.
-func resolveIssueKey(args []string) (string, error) {
-	if len(args) > 0 {
-		return args[0], nil
-	}
-	branch, err := git.GetCurrentBranch()
-	if err != nil {
-		return "", fmt.Errorf("課題キーを指定するか、課題キーを含むブランチに切り替えてください")
-	}
-	key := git.ExtractIssueKey(branch)
-	if key == "" {
-		return "", fmt.Errorf("課題キーを指定するか、課題キーを含むブランチに切り替えてください")
-	}
-	return key, nil
-}
-
 func newViewCmd() *cobra.Command {
-	var web bool
+	var (
+		web      bool
+		exporter *cmdutil.Exporter
+	)
 
 	cmd := &cobra.Command{
 		Use:   "view [issueKey]",
 		Short: "課題の詳細を表示する",
 		Args:  cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
 			cfg, client, err := cmdutil.LoadConfigAndClient()
 			if err != nil {
 				return err
 			}
 
-			issueKey, err := resolveIssueKey(args)
+			issueKey, err := cmdutil.ResolveIssueKey(cfg, args)
 			if err != nil {
 				return err
 			}
@@ -58,11 +49,15 @@ func newViewCmd() *cobra.Command {
 				return browser.Open(url)
 			}
 
-			issue, err := client.GetIssue(issueKey)
+			issue, err := client.GetIssue(ctx, issueKey)
 			if err != nil {
 				return err
 			}
 
+			if exporter.Enabled() {
+				return exporter.Write(os.Stdout, issue)
+			}
+
 			// Title
 			fmt.Println(titleStyle.Render(issue.IssueKey + " " + issue.Summary))
 			fmt.Println()
@@ -72,6 +67,9 @@ func newViewCmd() *cobra.Command {
 			if issue.Status != nil {
 				meta = append(meta, statusColor(issue.Status.Name).Render(issue.Status.Name))
 			}
+			if issue.Resolution != nil {
+				meta = append(meta, labelStyle.Render("完了理由: ")+issue.Resolution.Name)
+			}
 			if issue.Priority != nil {
 				meta = append(meta, issue.Priority.Name)
 			}
@@ -99,6 +97,11 @@ func newViewCmd() *cobra.Command {
 				fmt.Println(labelStyle.Render("期日: ") + issue.DueDate)
 			}
 
+			// Estimated and actual hours
+			if issue.EstimatedHours != nil || issue.ActualHours != nil {
+				fmt.Println(labelStyle.Render("時間: ") + hoursSummary(issue))
+			}
+
 			// Milestones
 			if len(issue.Milestone) > 0 {
 				var names []string
@@ -108,12 +111,54 @@ func newViewCmd() *cobra.Command {
 				fmt.Println(labelStyle.Render("マイルストーン: ") + strings.Join(names, ", "))
 			}
 
+			// Custom fields
+			for _, v := range issue.CustomFields {
+				if val := v.String(); val != "" {
+					fmt.Println(labelStyle.Render(v.Name+": ") + val)
+				}
+			}
+
+			// Attachments
+			if len(issue.Attachments) > 0 {
+				var names []string
+				for _, a := range issue.Attachments {
+					names = append(names, fmt.Sprintf("%s (%d)", a.Name, a.ID))
+				}
+				fmt.Println(labelStyle.Render("添付ファイル: ") + strings.Join(names, ", "))
+			}
+
+			// Parent issue
+			if issue.ParentIssueID != nil {
+				parent, err := client.GetIssue(ctx, strconv.Itoa(*issue.ParentIssueID))
+				if err != nil {
+					return err
+				}
+				fmt.Println(labelStyle.Render("親課題: ") + parent.IssueKey + " " + parent.Summary)
+			}
+
 			// Description
 			if issue.Description != "" {
 				fmt.Println()
 				fmt.Println(issue.Description)
 			}
 
+			// Child issues
+			if issue.ParentIssueID == nil {
+				children, err := client.GetChildIssues(ctx, issue)
+				if err != nil {
+					return err
+				}
+				if len(children) > 0 {
+					projectKey, _, _ := strings.Cut(issue.IssueKey, "-")
+					done := countClosed(children, space.Projects[projectKey].CloseStatus)
+					fmt.Println()
+					fmt.Println(labelStyle.Render(fmt.Sprintf("子課題: %d/%d 完了（%d%%）", done, len(children), done*100/len(children))))
+					for _, child := range children {
+						fmt.Println("  " + child.IssueKey + " " + issueStatusLabel(&child) + " " + child.Summary)
+					}
+				}
+			}
+
 			// URL
 			fmt.Println()
 			fmt.Println(labelStyle.Render("URL: ") + urlStyle.Render(space.SpaceURL+"/view/"+issue.IssueKey))
@@ -123,6 +168,7 @@ func newViewCmd() *cobra.Command {
 	}
 
 	cmd.Flags().BoolVarP(&web, "web", "w", false, "ブラウザで開く")
+	exporter = cmdutil.AddJSONFlags(cmd, cmdutil.StructFields(api.Issue{}))
 
 	return cmd
 }
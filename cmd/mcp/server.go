@@ -5,22 +5,35 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"strings"
 
 	"github.com/KimMaru10/bl-cli/internal/api"
+	"github.com/KimMaru10/bl-cli/internal/cmdutil"
 	"github.com/KimMaru10/bl-cli/internal/config"
+	"github.com/KimMaru10/bl-cli/internal/search"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
 
 func newClient() (*api.Client, string, error) {
-	cfg, err := config.Load()
+	client, space, err := newSpaceClient()
 	if err != nil {
-		return nil, "", fmt.Errorf("設定の読み込みに失敗しました: %w", err)
+		return nil, "", err
 	}
-	space := cfg.Current()
-	if space == nil {
-		return nil, "", fmt.Errorf("認証されていません。先に bl auth login を実行してください")
+	return client, space.DefaultProject, nil
+}
+
+// newSpaceClient is like newClient but returns the space configuration,
+// for tools that need per-project settings.
+func newSpaceClient() (*api.Client, *config.SpaceConfig, error) {
+	cfg, err := cmdutil.LoadConfig()
+	if err != nil {
+		return nil, nil, err
+	}
+	space, err := cmdutil.CurrentSpace(cfg)
+	if err != nil {
+		return nil, nil, err
 	}
-	return api.NewClient(space.SpaceURL, space.APIKey), space.DefaultProject, nil
+	return cmdutil.NewSpaceClient(cfg.CurrentName(), space), space, nil
 }
 
 func textResult(v any) (*mcp.CallToolResult, any, error) {
@@ -35,6 +48,16 @@ func textResult(v any) (*mcp.CallToolResult, any, error) {
 	}, nil, nil
 }
 
+// apiErrResult reports err to the model along with a hint on how to
+// recover, so it can tell a missing issue from a permission problem.
+func apiErrResult(err error) (*mcp.CallToolResult, any, error) {
+	msg := err.Error()
+	if hint := cmdutil.Hint(err); hint != "" {
+		msg += "\nヒント: " + hint
+	}
+	return errResult(msg)
+}
+
 func errResult(msg string) (*mcp.CallToolResult, any, error) {
 	return &mcp.CallToolResult{
 		Content: []mcp.Content{
@@ -53,7 +76,9 @@ type issueListArgs struct {
 	Status     string `json:"status,omitempty" jsonschema:"ステータスで絞り込み（例：処理中、完了）"`
 	AssigneeMe bool   `json:"assignee_me,omitempty" jsonschema:"自分にアサインされた課題のみ"`
 	Keyword    string `json:"keyword,omitempty" jsonschema:"キーワード検索"`
-	Count      int    `json:"count,omitempty" jsonschema:"取得件数（デフォルト20、最大100）"`
+	Query      string `json:"query,omitempty" jsonschema:"検索クエリ（例：status:処理中 assignee:@me due:<2026-11-01 type:バグ is:open）。他の条件と組み合わせた場合はすべてを満たす課題を返す"`
+	Count      int    `json:"count,omitempty" jsonschema:"取得件数（デフォルト20。100件を超える場合は自動でページング）"`
+	All        bool   `json:"all,omitempty" jsonschema:"該当する課題をすべて取得する（count は無視される）"`
 }
 
 type issueViewArgs struct {
@@ -71,17 +96,20 @@ type issueCreateArgs struct {
 }
 
 type issueEditArgs struct {
-	IssueKey     string `json:"issue_key" jsonschema:"課題キー（例：PROJ-123）"`
-	Status       string `json:"status,omitempty" jsonschema:"変更先のステータス名"`
-	AssigneeName string `json:"assignee_name,omitempty" jsonschema:"担当者名"`
-	Priority     string `json:"priority,omitempty" jsonschema:"優先度名"`
-	DueDate      string `json:"due_date,omitempty" jsonschema:"期日（yyyy-MM-dd）"`
-	Comment      string `json:"comment,omitempty" jsonschema:"更新時のコメント"`
+	IssueKey     string   `json:"issue_key" jsonschema:"課題キー（例：PROJ-123）"`
+	Status       string   `json:"status,omitempty" jsonschema:"変更先のステータス名"`
+	Resolution   string   `json:"resolution,omitempty" jsonschema:"完了理由（例：対応済み、対応しない、重複、無効、再現しない）"`
+	AssigneeName string   `json:"assignee_name,omitempty" jsonschema:"担当者名"`
+	Priority     string   `json:"priority,omitempty" jsonschema:"優先度名"`
+	DueDate      string   `json:"due_date,omitempty" jsonschema:"期日（yyyy-MM-dd）"`
+	Comment      string   `json:"comment,omitempty" jsonschema:"更新時のコメント（@ユーザーID でメンション）"`
+	Notify       []string `json:"notify,omitempty" jsonschema:"通知するユーザー名またはユーザーID"`
 }
 
 type commentAddArgs struct {
-	IssueKey string `json:"issue_key" jsonschema:"課題キー（例：PROJ-123）"`
-	Body     string `json:"body" jsonschema:"コメント本文"`
+	IssueKey string   `json:"issue_key" jsonschema:"課題キー（例：PROJ-123）"`
+	Body     string   `json:"body" jsonschema:"コメント本文（@ユーザーID でメンション）"`
+	Notify   []string `json:"notify,omitempty" jsonschema:"通知するユーザー名またはユーザーID"`
 }
 
 type commentListArgs struct {
@@ -89,21 +117,40 @@ type commentListArgs struct {
 	Count    int    `json:"count,omitempty" jsonschema:"取得件数（デフォルト20）"`
 }
 
-// Run starts the MCP server over stdio.
-func Run() {
+type issueDeleteArgs struct {
+	IssueKey string `json:"issue_key" jsonschema:"削除する課題キー（例：PROJ-123）"`
+}
+
+type commentDeleteArgs struct {
+	IssueKey  string `json:"issue_key" jsonschema:"課題キー（例：PROJ-123）"`
+	CommentID int    `json:"comment_id" jsonschema:"削除するコメントの ID（comment_list で取得）"`
+}
+
+// Options configures the MCP server.
+type Options struct {
+	// AllowDelete exposes the issue_delete and comment_delete tools.
+	AllowDelete bool
+}
+
+// Run starts the MCP server over stdio. In-flight tool calls are
+// cancelled when ctx is done.
+func Run(ctx context.Context, opts Options) {
+	// stdio carries the protocol, so secrets cannot be prompted for
+	config.PromptPassphrase = nil
+
 	server := mcp.NewServer(&mcp.Implementation{
 		Name:    "bl-backlog",
 		Version: "0.2.1",
 	}, nil)
 
-	registerTools(server)
+	registerTools(server, opts)
 
-	if err := server.Run(context.Background(), &mcp.StdioTransport{}); err != nil {
+	if err := server.Run(ctx, &mcp.StdioTransport{}); err != nil {
 		log.Fatalf("MCP server error: %v", err)
 	}
 }
 
-func registerTools(server *mcp.Server) {
+func registerTools(server *mcp.Server, opts Options) {
 	// project_list
 	mcp.AddTool(server, &mcp.Tool{
 		Name:        "project_list",
@@ -113,7 +160,7 @@ func registerTools(server *mcp.Server) {
 	// issue_list
 	mcp.AddTool(server, &mcp.Tool{
 		Name:        "issue_list",
-		Description: "Backlog の課題一覧を取得する。ステータスやキーワードで絞り込み可能",
+		Description: "Backlog の課題一覧を取得する。ステータスやキーワード、検索クエリで絞り込み可能。\n\n" + search.Syntax,
 	}, handleIssueList)
 
 	// issue_view
@@ -145,6 +192,23 @@ func registerTools(server *mcp.Server) {
 		Name:        "comment_list",
 		Description: "Backlog の課題のコメント一覧を取得する",
 	}, handleCommentList)
+
+	// Destructive tools are only exposed when explicitly allowed
+	if !opts.AllowDelete {
+		return
+	}
+
+	// issue_delete
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "issue_delete",
+		Description: "Backlog の課題を削除する。削除は元に戻せないため、実行前に必ずユーザーの確認を取ること",
+	}, handleIssueDelete)
+
+	// comment_delete
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "comment_delete",
+		Description: "Backlog の課題のコメントを削除する。削除は元に戻せないため、実行前に必ずユーザーの確認を取ること",
+	}, handleCommentDelete)
 }
 
 // --- Handlers ---
@@ -152,11 +216,11 @@ func registerTools(server *mcp.Server) {
 func handleProjectList(ctx context.Context, req *mcp.CallToolRequest, args projectListArgs) (*mcp.CallToolResult, any, error) {
 	client, _, err := newClient()
 	if err != nil {
-		return errResult(err.Error())
+		return apiErrResult(err)
 	}
-	projects, err := client.GetProjects()
+	projects, err := client.GetProjects(ctx)
 	if err != nil {
-		return errResult(err.Error())
+		return apiErrResult(err)
 	}
 	type item struct {
 		Key  string `json:"key"`
@@ -170,57 +234,55 @@ func handleProjectList(ctx context.Context, req *mcp.CallToolRequest, args proje
 }
 
 func handleIssueList(ctx context.Context, req *mcp.CallToolRequest, args issueListArgs) (*mcp.CallToolResult, any, error) {
-	client, defaultProject, err := newClient()
+	client, space, err := newSpaceClient()
 	if err != nil {
-		return errResult(err.Error())
+		return apiErrResult(err)
 	}
 
 	projectKey := args.ProjectKey
 	if projectKey == "" {
-		projectKey = defaultProject
+		projectKey = space.DefaultProject
 	}
 	if projectKey == "" {
 		return errResult("project_key を指定してください（デフォルトプロジェクトが未設定です）")
 	}
 
-	project, err := client.GetProject(projectKey)
+	project, err := client.GetProject(ctx, projectKey)
 	if err != nil {
-		return errResult(err.Error())
+		return apiErrResult(err)
 	}
 
 	opts := &api.GetIssuesOptions{
 		ProjectIDs: []int{project.ID},
-		Count:      args.Count,
 	}
 
+	q, err := search.Parse(args.Query)
+	if err != nil {
+		return errResult(err.Error())
+	}
 	if args.AssigneeMe {
-		me, err := client.GetMyself()
-		if err != nil {
-			return errResult(err.Error())
-		}
-		opts.AssigneeIDs = []int{me.ID}
+		q.Assignees = append(q.Assignees, []string{"@me"})
 	}
-
 	if args.Status != "" {
-		statuses, err := client.GetStatuses(projectKey)
-		if err != nil {
-			return errResult(err.Error())
-		}
-		for _, s := range statuses {
-			if s.Name == args.Status {
-				opts.StatusIDs = []int{s.ID}
-				break
-			}
-		}
+		q.Statuses = append(q.Statuses, []string{args.Status})
 	}
-
 	if args.Keyword != "" {
-		opts.Keyword = args.Keyword
+		q.Keywords = append(q.Keywords, args.Keyword)
+	}
+	if err := q.Resolve(ctx, client, projectKey, space.Projects[projectKey].CloseStatus, opts); err != nil {
+		return apiErrResult(err)
 	}
 
-	issues, err := client.GetIssues(opts)
+	limit := args.Count
+	if limit <= 0 {
+		limit = 20
+	}
+	if args.All {
+		limit = 0
+	}
+	issues, err := client.ListIssues(ctx, opts, limit)
 	if err != nil {
-		return errResult(err.Error())
+		return apiErrResult(err)
 	}
 
 	type item struct {
@@ -255,27 +317,29 @@ func handleIssueList(ctx context.Context, req *mcp.CallToolRequest, args issueLi
 func handleIssueView(ctx context.Context, req *mcp.CallToolRequest, args issueViewArgs) (*mcp.CallToolResult, any, error) {
 	client, _, err := newClient()
 	if err != nil {
-		return errResult(err.Error())
+		return apiErrResult(err)
 	}
-	issue, err := client.GetIssue(args.IssueKey)
+	issue, err := client.GetIssue(ctx, args.IssueKey)
 	if err != nil {
-		return errResult(err.Error())
+		return apiErrResult(err)
 	}
 
 	type result struct {
-		Key         string   `json:"key"`
-		Summary     string   `json:"summary"`
-		Description string   `json:"description"`
-		Status      string   `json:"status"`
-		Assignee    string   `json:"assignee"`
-		Priority    string   `json:"priority"`
-		IssueType   string   `json:"issue_type"`
-		DueDate     string   `json:"due_date,omitempty"`
-		StartDate   string   `json:"start_date,omitempty"`
-		Created     string   `json:"created"`
-		Updated     string   `json:"updated"`
-		Milestones  []string `json:"milestones,omitempty"`
-		Categories  []string `json:"categories,omitempty"`
+		Key          string            `json:"key"`
+		Summary      string            `json:"summary"`
+		Description  string            `json:"description"`
+		Status       string            `json:"status"`
+		Assignee     string            `json:"assignee"`
+		Priority     string            `json:"priority"`
+		IssueType    string            `json:"issue_type"`
+		DueDate      string            `json:"due_date,omitempty"`
+		StartDate    string            `json:"start_date,omitempty"`
+		Created      string            `json:"created"`
+		Updated      string            `json:"updated"`
+		Milestones   []string          `json:"milestones,omitempty"`
+		Categories   []string          `json:"categories,omitempty"`
+		Attachments  []string          `json:"attachments,omitempty"`
+		CustomFields map[string]string `json:"custom_fields,omitempty"`
 	}
 
 	r := result{
@@ -305,6 +369,17 @@ func handleIssueView(ctx context.Context, req *mcp.CallToolRequest, args issueVi
 	for _, c := range issue.Category {
 		r.Categories = append(r.Categories, c.Name)
 	}
+	for _, a := range issue.Attachments {
+		r.Attachments = append(r.Attachments, a.Name)
+	}
+	for _, v := range issue.CustomFields {
+		if val := v.String(); val != "" {
+			if r.CustomFields == nil {
+				r.CustomFields = map[string]string{}
+			}
+			r.CustomFields[v.Name] = val
+		}
+	}
 
 	return textResult(r)
 }
@@ -312,7 +387,7 @@ func handleIssueView(ctx context.Context, req *mcp.CallToolRequest, args issueVi
 func handleIssueCreate(ctx context.Context, req *mcp.CallToolRequest, args issueCreateArgs) (*mcp.CallToolResult, any, error) {
 	client, defaultProject, err := newClient()
 	if err != nil {
-		return errResult(err.Error())
+		return apiErrResult(err)
 	}
 
 	projectKey := args.ProjectKey
@@ -323,15 +398,15 @@ func handleIssueCreate(ctx context.Context, req *mcp.CallToolRequest, args issue
 		return errResult("project_key を指定してください")
 	}
 
-	project, err := client.GetProject(projectKey)
+	project, err := client.GetProject(ctx, projectKey)
 	if err != nil {
-		return errResult(err.Error())
+		return apiErrResult(err)
 	}
 
 	// Resolve issue type
-	issueTypes, err := client.GetIssueTypes(projectKey)
+	issueTypes, err := client.GetIssueTypes(ctx, projectKey)
 	if err != nil {
-		return errResult(err.Error())
+		return apiErrResult(err)
 	}
 	var issueTypeID int
 	for _, t := range issueTypes {
@@ -349,9 +424,9 @@ func handleIssueCreate(ctx context.Context, req *mcp.CallToolRequest, args issue
 	}
 
 	// Resolve priority
-	priorities, err := client.GetPriorities()
+	priorities, err := client.GetPriorities(ctx)
 	if err != nil {
-		return errResult(err.Error())
+		return apiErrResult(err)
 	}
 	var priorityID int
 	for _, p := range priorities {
@@ -379,9 +454,9 @@ func handleIssueCreate(ctx context.Context, req *mcp.CallToolRequest, args issue
 
 	// Resolve assignee
 	if args.AssigneeName != "" {
-		users, err := client.GetProjectUsers(projectKey)
+		users, err := client.GetProjectUsers(ctx, projectKey)
 		if err != nil {
-			return errResult(err.Error())
+			return apiErrResult(err)
 		}
 		for _, u := range users {
 			if u.Name == args.AssigneeName {
@@ -394,9 +469,9 @@ func handleIssueCreate(ctx context.Context, req *mcp.CallToolRequest, args issue
 		}
 	}
 
-	issue, err := client.CreateIssue(opts)
+	issue, err := client.CreateIssue(ctx, opts)
 	if err != nil {
-		return errResult(err.Error())
+		return apiErrResult(err)
 	}
 
 	return textResult(map[string]string{
@@ -409,21 +484,21 @@ func handleIssueCreate(ctx context.Context, req *mcp.CallToolRequest, args issue
 func handleIssueEdit(ctx context.Context, req *mcp.CallToolRequest, args issueEditArgs) (*mcp.CallToolResult, any, error) {
 	client, _, err := newClient()
 	if err != nil {
-		return errResult(err.Error())
+		return apiErrResult(err)
 	}
 
 	// Get current issue to determine project
-	issue, err := client.GetIssue(args.IssueKey)
+	issue, err := client.GetIssue(ctx, args.IssueKey)
 	if err != nil {
-		return errResult(err.Error())
+		return apiErrResult(err)
 	}
 
 	opts := &api.UpdateIssueOptions{}
 
 	if args.Status != "" {
-		statuses, err := client.GetStatuses(fmt.Sprintf("%d", issue.ProjectID))
+		statuses, err := client.GetStatuses(ctx, fmt.Sprintf("%d", issue.ProjectID))
 		if err != nil {
-			return errResult(err.Error())
+			return apiErrResult(err)
 		}
 		found := false
 		for _, s := range statuses {
@@ -442,10 +517,27 @@ func handleIssueEdit(ctx context.Context, req *mcp.CallToolRequest, args issueEd
 		}
 	}
 
+	if args.Resolution != "" {
+		resolutions, err := client.GetResolutions(ctx)
+		if err != nil {
+			return apiErrResult(err)
+		}
+		names := make([]string, len(resolutions))
+		for i, r := range resolutions {
+			if r.Name == args.Resolution {
+				opts.ResolutionID = &r.ID
+			}
+			names[i] = r.Name
+		}
+		if opts.ResolutionID == nil {
+			return errResult(fmt.Sprintf("完了理由「%s」が見つかりません。選択肢: %v", args.Resolution, names))
+		}
+	}
+
 	if args.Priority != "" {
-		priorities, err := client.GetPriorities()
+		priorities, err := client.GetPriorities(ctx)
 		if err != nil {
-			return errResult(err.Error())
+			return apiErrResult(err)
 		}
 		found := false
 		for _, p := range priorities {
@@ -461,9 +553,9 @@ func handleIssueEdit(ctx context.Context, req *mcp.CallToolRequest, args issueEd
 	}
 
 	if args.AssigneeName != "" {
-		users, err := client.GetProjectUsers(fmt.Sprintf("%d", issue.ProjectID))
+		users, err := client.GetProjectUsers(ctx, fmt.Sprintf("%d", issue.ProjectID))
 		if err != nil {
-			return errResult(err.Error())
+			return apiErrResult(err)
 		}
 		found := false
 		for _, u := range users {
@@ -486,9 +578,17 @@ func handleIssueEdit(ctx context.Context, req *mcp.CallToolRequest, args issueEd
 		opts.Comment = &args.Comment
 	}
 
-	updated, err := client.UpdateIssue(args.IssueKey, opts)
+	if len(args.Notify) > 0 || strings.Contains(args.Comment, "@") {
+		ids, err := cmdutil.NotifiedUserIDs(ctx, client, fmt.Sprintf("%d", issue.ProjectID), args.Notify, args.Comment)
+		if err != nil {
+			return apiErrResult(err)
+		}
+		opts.NotifiedUserIDs = ids
+	}
+
+	updated, err := client.UpdateIssue(ctx, args.IssueKey, opts)
 	if err != nil {
-		return errResult(err.Error())
+		return apiErrResult(err)
 	}
 
 	r := map[string]string{
@@ -504,36 +604,50 @@ func handleIssueEdit(ctx context.Context, req *mcp.CallToolRequest, args issueEd
 func handleCommentAdd(ctx context.Context, req *mcp.CallToolRequest, args commentAddArgs) (*mcp.CallToolResult, any, error) {
 	client, _, err := newClient()
 	if err != nil {
-		return errResult(err.Error())
+		return apiErrResult(err)
 	}
-	comment, err := client.AddComment(args.IssueKey, args.Body)
+	opts := &api.AddCommentOptions{Content: args.Body}
+	if len(args.Notify) > 0 || strings.Contains(args.Body, "@") {
+		projectKey, err := cmdutil.IssueProjectKey(ctx, client, args.IssueKey)
+		if err != nil {
+			return apiErrResult(err)
+		}
+		ids, err := cmdutil.NotifiedUserIDs(ctx, client, projectKey, args.Notify, args.Body)
+		if err != nil {
+			return apiErrResult(err)
+		}
+		opts.NotifiedUserIDs = ids
+	}
+	comment, err := client.AddComment(ctx, args.IssueKey, opts)
 	if err != nil {
-		return errResult(err.Error())
+		return apiErrResult(err)
 	}
 	return textResult(map[string]any{
-		"comment_id": comment.ID,
-		"message":    fmt.Sprintf("%s にコメントを追加しました", args.IssueKey),
+		"comment_id":        comment.ID,
+		"notified_user_ids": opts.NotifiedUserIDs,
+		"message":           fmt.Sprintf("%s にコメントを追加しました", args.IssueKey),
 	})
 }
 
 func handleCommentList(ctx context.Context, req *mcp.CallToolRequest, args commentListArgs) (*mcp.CallToolResult, any, error) {
 	client, _, err := newClient()
 	if err != nil {
-		return errResult(err.Error())
+		return apiErrResult(err)
 	}
 	count := args.Count
 	if count <= 0 {
 		count = 20
 	}
-	comments, err := client.GetComments(args.IssueKey, count, "desc")
+	comments, err := client.GetComments(ctx, args.IssueKey, count, "desc")
 	if err != nil {
-		return errResult(err.Error())
+		return apiErrResult(err)
 	}
 	type item struct {
-		ID      int    `json:"id"`
-		Content string `json:"content"`
-		Author  string `json:"author"`
-		Created string `json:"created"`
+		ID          int      `json:"id"`
+		Content     string   `json:"content"`
+		Author      string   `json:"author"`
+		Created     string   `json:"created"`
+		Attachments []string `json:"attachments,omitempty"`
 	}
 	result := make([]item, len(comments))
 	for i, c := range comments {
@@ -545,7 +659,40 @@ func handleCommentList(ctx context.Context, req *mcp.CallToolRequest, args comme
 		if c.CreatedUser != nil {
 			it.Author = c.CreatedUser.Name
 		}
+		for _, a := range c.Attachments() {
+			it.Attachments = append(it.Attachments, a.Name)
+		}
 		result[i] = it
 	}
 	return textResult(result)
 }
+
+func handleIssueDelete(ctx context.Context, req *mcp.CallToolRequest, args issueDeleteArgs) (*mcp.CallToolResult, any, error) {
+	client, _, err := newClient()
+	if err != nil {
+		return apiErrResult(err)
+	}
+	issue, err := client.DeleteIssue(ctx, args.IssueKey)
+	if err != nil {
+		return apiErrResult(err)
+	}
+	return textResult(map[string]string{
+		"issue_key": issue.IssueKey,
+		"summary":   issue.Summary,
+		"message":   fmt.Sprintf("%s を削除しました", issue.IssueKey),
+	})
+}
+
+func handleCommentDelete(ctx context.Context, req *mcp.CallToolRequest, args commentDeleteArgs) (*mcp.CallToolResult, any, error) {
+	client, _, err := newClient()
+	if err != nil {
+		return apiErrResult(err)
+	}
+	if _, err := client.DeleteComment(ctx, args.IssueKey, args.CommentID); err != nil {
+		return apiErrResult(err)
+	}
+	return textResult(map[string]string{
+		"issue_key": args.IssueKey,
+		"message":   fmt.Sprintf("%s のコメント %d を削除しました", args.IssueKey, args.CommentID),
+	})
+}
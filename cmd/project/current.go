@@ -3,7 +3,7 @@ package project
 import (
 	"fmt"
 
-	"github.com/KimMaru10/bl-cli/internal/config"
+	"github.com/KimMaru10/bl-cli/internal/cmdutil"
 	"github.com/spf13/cobra"
 )
 
@@ -12,11 +12,14 @@ func newCurrentCmd() *cobra.Command {
 		Use:   "current",
 		Short: "デフォルトプロジェクトを表示する",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			cfg, err := config.Load()
+			cfg, err := cmdutil.LoadConfig()
 			if err != nil {
-				return fmt.Errorf("設定の読み込みに失敗しました: %w", err)
+				return err
 			}
 
+			if err := cfg.CheckSpace(); err != nil {
+				return err
+			}
 			space := cfg.Current()
 			if space == nil || space.DefaultProject == "" {
 				fmt.Println("デフォルトプロジェクトが未設定です。bl project set を実行してください")
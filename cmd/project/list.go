@@ -2,7 +2,9 @@ package project
 
 import (
 	"fmt"
+	"os"
 
+	"github.com/KimMaru10/bl-cli/internal/api"
 	"github.com/KimMaru10/bl-cli/internal/cmdutil"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/spf13/cobra"
@@ -11,20 +13,28 @@ import (
 var headerStyle = lipgloss.NewStyle().Bold(true)
 
 func newListCmd() *cobra.Command {
-	return &cobra.Command{
+	var exporter *cmdutil.Exporter
+
+	cmd := &cobra.Command{
 		Use:   "list",
 		Short: "プロジェクト一覧を表示する",
 		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
 			_, client, err := cmdutil.LoadConfigAndClient()
 			if err != nil {
 				return err
 			}
 
-			projects, err := client.GetProjects()
+			projects, err := client.GetProjects(ctx)
 			if err != nil {
 				return err
 			}
 
+			if exporter.Enabled() {
+				return exporter.Write(os.Stdout, projects)
+			}
+
 			if len(projects) == 0 {
 				fmt.Println("プロジェクトがありません")
 				return nil
@@ -37,4 +47,8 @@ func newListCmd() *cobra.Command {
 			return nil
 		},
 	}
+
+	exporter = cmdutil.AddJSONFlags(cmd, cmdutil.StructFields(api.Project{}))
+
+	return cmd
 }
@@ -80,16 +80,20 @@ func (m setModel) View() string {
 }
 
 func newSetCmd() *cobra.Command {
-	return &cobra.Command{
+	var local bool
+
+	cmd := &cobra.Command{
 		Use:   "set",
 		Short: "デフォルトプロジェクトを設定する",
 		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
 			cfg, client, err := cmdutil.LoadConfigAndClient()
 			if err != nil {
 				return err
 			}
 
-			projects, err := client.GetProjects()
+			projects, err := client.GetProjects(ctx)
 			if err != nil {
 				return err
 			}
@@ -116,9 +120,17 @@ func newSetCmd() *cobra.Command {
 				return nil
 			}
 
-			space := cfg.Spaces[cfg.CurrentSpace]
+			name := cfg.CurrentName()
+			if local {
+				return saveLocal(name, result.selected)
+			}
+
+			space, ok := cfg.Spaces[name]
+			if !ok {
+				return fmt.Errorf("環境変数で指定されたスペースにはデフォルトプロジェクトを保存できません。BL_PROJECT を使用してください")
+			}
 			space.DefaultProject = result.selected
-			cfg.Spaces[cfg.CurrentSpace] = space
+			cfg.Spaces[name] = space
 			if err := config.Save(cfg); err != nil {
 				return fmt.Errorf("設定の保存に失敗しました: %w", err)
 			}
@@ -127,4 +139,31 @@ func newSetCmd() *cobra.Command {
 			return nil
 		},
 	}
+
+	cmd.Flags().BoolVar(&local, "local", false, "このリポジトリの "+config.LocalConfigFile+" に保存する")
+
+	return cmd
+}
+
+// saveLocal writes the space and project to the repository's .bl.yaml,
+// keeping its other settings.
+func saveLocal(space, projectKey string) error {
+	p, err := config.LocalConfigPath()
+	if err != nil {
+		return err
+	}
+	local, err := config.ReadLocal(p)
+	if err != nil {
+		return err
+	}
+	if space != "" {
+		local.Space = space
+	}
+	local.Project = projectKey
+	if err := config.SaveLocal(p, local); err != nil {
+		return err
+	}
+
+	fmt.Println(successStyle.Render("✔ " + p + " のプロジェクトを " + projectKey + " に設定しました"))
+	return nil
 }
@@ -0,0 +1,250 @@
+package release
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/KimMaru10/bl-cli/internal/api"
+	"github.com/KimMaru10/bl-cli/internal/cmdutil"
+	"github.com/KimMaru10/bl-cli/internal/git"
+	"github.com/spf13/cobra"
+)
+
+// Ways of grouping issues in release notes.
+const (
+	groupByType      = "type"
+	groupByCategory  = "category"
+	groupByMilestone = "milestone"
+	groupByNone      = "none"
+)
+
+var groupByNames = []string{groupByType, groupByCategory, groupByMilestone, groupByNone}
+
+// ungrouped is the heading for issues without a category or milestone.
+const ungrouped = "未分類"
+
+// releaseNotes is the structured form of bl release notes, used for
+// --json, --jq and --template.
+type releaseNotes struct {
+	Range   string         `json:"range"`
+	Groups  []releaseGroup `json:"groups"`
+	Missing []string       `json:"missing"`
+}
+
+type releaseGroup struct {
+	Name   string         `json:"name"`
+	Issues []releaseIssue `json:"issues"`
+}
+
+type releaseIssue struct {
+	IssueKey   string   `json:"issueKey"`
+	Summary    string   `json:"summary"`
+	IssueType  string   `json:"issueType"`
+	Status     string   `json:"status"`
+	Assignee   string   `json:"assignee"`
+	Categories []string `json:"categories"`
+	Milestones []string `json:"milestones"`
+	URL        string   `json:"url"`
+}
+
+func newNotesCmd() *cobra.Command {
+	var (
+		groupBy  string
+		exporter *cmdutil.Exporter
+	)
+
+	cmd := &cobra.Command{
+		Use:   "notes <from>..<to>",
+		Short: "コミット履歴の課題からリリースノートを作成する",
+		Long: `指定した範囲のコミットメッセージから課題キーを集め、課題の情報を取得して
+Markdown のリリースノートを出力します。<to> を省略すると HEAD までを対象にします。
+
+--template には次の構造のデータが渡されます。
+  .range                       範囲
+  .groups[].name               グループ名
+  .groups[].issues[]           issueKey, summary, issueType, status, assignee,
+                               categories, milestones, url
+  .missing                     見つからなかった課題キー`,
+		Example: `  bl release notes v1.2.0..v1.3.0
+  bl release notes v1.2.0 --group-by milestone
+  bl release notes v1.2.0..HEAD --template '{{range .groups}}{{range .issues}}{{.issueKey}} {{.summary}}{{"\n"}}{{end}}{{end}}'`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			if !slices.Contains(groupByNames, groupBy) {
+				return fmt.Errorf("--group-by には %s のいずれかを指定してください", strings.Join(groupByNames, ", "))
+			}
+
+			revRange := args[0]
+			if !strings.Contains(revRange, "..") {
+				revRange += "..HEAD"
+			}
+
+			cfg, client, err := cmdutil.LoadConfigAndClient()
+			if err != nil {
+				return err
+			}
+			space := cfg.Current()
+
+			m, err := cmdutil.IssueKeyMatcher(cfg)
+			if err != nil {
+				return err
+			}
+			msgs, err := git.CommitMessages(revRange)
+			if err != nil {
+				return fmt.Errorf("コミット履歴の取得に失敗しました: %w", err)
+			}
+			var keys []string
+			for _, msg := range msgs {
+				for _, key := range m.FindAll(msg) {
+					if !slices.Contains(keys, key) {
+						keys = append(keys, key)
+					}
+				}
+			}
+
+			issues, missing, err := client.GetIssuesByKey(ctx, keys)
+			if err != nil {
+				return err
+			}
+			for _, key := range missing {
+				fmt.Fprintln(os.Stderr, "警告: 課題 "+key+" が見つからないためスキップしました")
+			}
+
+			notes := releaseNotes{
+				Range:   revRange,
+				Groups:  groupIssues(issues, groupBy, space.SpaceURL),
+				Missing: missing,
+			}
+
+			if exporter.Enabled() {
+				return exporter.Write(os.Stdout, notes)
+			}
+			if len(issues) == 0 {
+				fmt.Fprintln(os.Stderr, revRange+" のコミットに課題キーは見つかりませんでした")
+				return nil
+			}
+			return writeMarkdown(os.Stdout, notes)
+		},
+	}
+
+	cmd.Flags().StringVarP(&groupBy, "group-by", "g", groupByType, "グループ分けの基準（"+strings.Join(groupByNames, ", ")+"）")
+	exporter = cmdutil.AddJSONFlags(cmd, cmdutil.StructFields(releaseNotes{}))
+
+	return cmd
+}
+
+// groupIssues sorts issues by key and groups them by issue type, category
+// or milestone. An issue with several categories or milestones appears in
+// each of them.
+func groupIssues(issues []api.Issue, groupBy, spaceURL string) []releaseGroup {
+	slices.SortFunc(issues, func(a, b api.Issue) int { return compareKeys(a.IssueKey, b.IssueKey) })
+
+	var groups []releaseGroup
+	add := func(name string, issue releaseIssue) {
+		i := slices.IndexFunc(groups, func(g releaseGroup) bool { return g.Name == name })
+		if i < 0 {
+			groups = append(groups, releaseGroup{Name: name})
+			i = len(groups) - 1
+		}
+		groups[i].Issues = append(groups[i].Issues, issue)
+	}
+
+	for _, issue := range issues {
+		ri := toReleaseIssue(issue, spaceURL)
+
+		var names []string
+		switch groupBy {
+		case groupByType:
+			names = []string{ri.IssueType}
+		case groupByCategory:
+			names = ri.Categories
+		case groupByMilestone:
+			names = ri.Milestones
+		case groupByNone:
+			names = []string{""}
+		}
+		if len(names) == 0 || (names[0] == "" && groupBy != groupByNone) {
+			names = []string{ungrouped}
+		}
+		for _, name := range names {
+			add(name, ri)
+		}
+	}
+
+	slices.SortStableFunc(groups, func(a, b releaseGroup) int {
+		switch {
+		case a.Name == ungrouped:
+			return 1
+		case b.Name == ungrouped:
+			return -1
+		}
+		return cmp.Compare(a.Name, b.Name)
+	})
+	return groups
+}
+
+func toReleaseIssue(issue api.Issue, spaceURL string) releaseIssue {
+	ri := releaseIssue{
+		IssueKey:   issue.IssueKey,
+		Summary:    issue.Summary,
+		URL:        spaceURL + "/view/" + issue.IssueKey,
+		Categories: []string{},
+		Milestones: []string{},
+	}
+	if issue.IssueType != nil {
+		ri.IssueType = issue.IssueType.Name
+	}
+	if issue.Status != nil {
+		ri.Status = issue.Status.Name
+	}
+	if issue.Assignee != nil {
+		ri.Assignee = issue.Assignee.Name
+	}
+	for _, c := range issue.Category {
+		ri.Categories = append(ri.Categories, c.Name)
+	}
+	for _, m := range issue.Milestone {
+		ri.Milestones = append(ri.Milestones, m.Name)
+	}
+	return ri
+}
+
+// compareKeys orders issue keys by project and then numerically.
+func compareKeys(a, b string) int {
+	pa, na, _ := strings.Cut(a, "-")
+	pb, nb, _ := strings.Cut(b, "-")
+	if c := cmp.Compare(pa, pb); c != 0 {
+		return c
+	}
+	ia, _ := strconv.Atoi(na)
+	ib, _ := strconv.Atoi(nb)
+	return cmp.Compare(ia, ib)
+}
+
+func writeMarkdown(w io.Writer, notes releaseNotes) error {
+	var b strings.Builder
+	for i, g := range notes.Groups {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		if g.Name != "" {
+			b.WriteString("## " + g.Name + "\n\n")
+		}
+		for _, issue := range g.Issues {
+			fmt.Fprintf(&b, "- [%s](%s) %s", issue.IssueKey, issue.URL, issue.Summary)
+			if issue.Assignee != "" {
+				b.WriteString("（担当: " + issue.Assignee + "）")
+			}
+			b.WriteString("\n")
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
@@ -0,0 +1,15 @@
+package release
+
+import "github.com/spf13/cobra"
+
+// NewReleaseCmd returns the release subcommand group.
+func NewReleaseCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "release",
+		Short: "リリースの支援",
+	}
+
+	cmd.AddCommand(newNotesCmd())
+
+	return cmd
+}
@@ -0,0 +1,292 @@
+package report
+
+import (
+	"cmp"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/KimMaru10/bl-cli/internal/api"
+	"github.com/KimMaru10/bl-cli/internal/cmdutil"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/cobra"
+)
+
+var (
+	headerStyle = lipgloss.NewStyle().Bold(true)
+	labelStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("8"))
+	overStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("1"))
+)
+
+// unassigned is the row name for issues without an assignee.
+const unassigned = "未割り当て"
+
+// hoursReport is the structured form of bl report hours, used for
+// --json, --jq and --template.
+type hoursReport struct {
+	Project   string       `json:"project"`
+	Milestone string       `json:"milestone,omitempty"`
+	User      string       `json:"user,omitempty"`
+	Total     hoursRow     `json:"total"`
+	Users     []hoursRow   `json:"users"`
+	Issues    []issueHours `json:"issues"`
+}
+
+type hoursRow struct {
+	Name      string  `json:"name"`
+	Issues    int     `json:"issues"`
+	Estimated float64 `json:"estimated"`
+	Actual    float64 `json:"actual"`
+}
+
+type issueHours struct {
+	IssueKey  string   `json:"issueKey"`
+	Summary   string   `json:"summary"`
+	Status    string   `json:"status"`
+	Assignee  string   `json:"assignee"`
+	Estimated *float64 `json:"estimated"`
+	Actual    *float64 `json:"actual"`
+}
+
+func (r *hoursRow) add(issue api.Issue) {
+	r.Issues++
+	if issue.EstimatedHours != nil {
+		r.Estimated += *issue.EstimatedHours
+	}
+	if issue.ActualHours != nil {
+		r.Actual += *issue.ActualHours
+	}
+}
+
+func newHoursCmd() *cobra.Command {
+	var (
+		project   string
+		milestone string
+		user      string
+		detail    bool
+		exporter  *cmdutil.Exporter
+	)
+
+	cmd := &cobra.Command{
+		Use:   "hours",
+		Short: "予定時間と実績時間を集計する",
+		Long: `課題の予定時間と実績時間を担当者ごとに集計します。
+
+--milestone と --user で対象の課題を絞り込めます。--detail を付けると
+課題ごとの内訳も表示します。`,
+		Example: `  bl report hours --milestone v1.2.0
+  bl report hours --milestone v1.2.0 --user @me --detail`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			cfg, client, err := cmdutil.LoadConfigAndClient()
+			if err != nil {
+				return err
+			}
+
+			projectKey := project
+			if projectKey == "" {
+				projectKey = cfg.Current().DefaultProject
+			}
+			if projectKey == "" {
+				return fmt.Errorf("プロジェクトを指定してください（--project または bl project set）")
+			}
+
+			proj, err := client.GetProject(ctx, projectKey)
+			if err != nil {
+				return err
+			}
+
+			opts := &api.GetIssuesOptions{
+				ProjectIDs: []int{proj.ID},
+				Sort:       "updated",
+				Order:      "desc",
+			}
+
+			if milestone != "" {
+				milestones, err := client.GetMilestones(ctx, projectKey)
+				if err != nil {
+					return err
+				}
+				i := slices.IndexFunc(milestones, func(m api.Milestone) bool { return m.Name == milestone })
+				if i < 0 {
+					return fmt.Errorf("マイルストーン '%s' が見つかりません", milestone)
+				}
+				opts.MilestoneIDs = []int{milestones[i].ID}
+			}
+
+			if user == "@me" {
+				me, err := client.GetMyself(ctx)
+				if err != nil {
+					return err
+				}
+				opts.AssigneeIDs = []int{me.ID}
+			} else if user != "" {
+				members, err := client.GetProjectUsers(ctx, projectKey)
+				if err != nil {
+					return err
+				}
+				found, err := api.FindUsers(members, []string{user})
+				if err != nil {
+					return err
+				}
+				opts.AssigneeIDs = api.UserIDs(found)
+			}
+
+			issues, err := client.ListIssues(ctx, opts, 0)
+			if err != nil {
+				return err
+			}
+
+			report := buildHoursReport(issues)
+			report.Project = proj.ProjectKey
+			report.Milestone = milestone
+			report.User = user
+
+			if exporter.Enabled() {
+				return exporter.Write(os.Stdout, report)
+			}
+
+			if len(issues) == 0 {
+				fmt.Println("該当する課題はありません")
+				return nil
+			}
+			writeHoursReport(report, detail)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&project, "project", "p", "", "プロジェクトキー")
+	cmd.Flags().StringVarP(&milestone, "milestone", "m", "", "マイルストーン名")
+	cmd.Flags().StringVarP(&user, "user", "u", "", "担当者名またはユーザーID（@me で自分）")
+	cmd.Flags().BoolVarP(&detail, "detail", "d", false, "課題ごとの内訳も表示する")
+	exporter = cmdutil.AddJSONFlags(cmd, cmdutil.StructFields(hoursReport{}))
+
+	return cmd
+}
+
+// buildHoursReport totals the hours of issues per assignee. Assignees
+// are sorted by actual hours, largest first.
+func buildHoursReport(issues []api.Issue) hoursReport {
+	report := hoursReport{
+		Total:  hoursRow{Name: "合計"},
+		Users:  []hoursRow{},
+		Issues: []issueHours{},
+	}
+	byUser := map[string]*hoursRow{}
+	for _, issue := range issues {
+		name := unassigned
+		if issue.Assignee != nil {
+			name = issue.Assignee.Name
+		}
+		row, ok := byUser[name]
+		if !ok {
+			row = &hoursRow{Name: name}
+			byUser[name] = row
+		}
+		row.add(issue)
+		report.Total.add(issue)
+
+		status := ""
+		if issue.Status != nil {
+			status = issue.Status.Name
+		}
+		report.Issues = append(report.Issues, issueHours{
+			IssueKey:  issue.IssueKey,
+			Summary:   issue.Summary,
+			Status:    status,
+			Assignee:  name,
+			Estimated: issue.EstimatedHours,
+			Actual:    issue.ActualHours,
+		})
+	}
+
+	for _, row := range byUser {
+		report.Users = append(report.Users, *row)
+	}
+	slices.SortFunc(report.Users, func(a, b hoursRow) int {
+		if c := cmp.Compare(b.Actual, a.Actual); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+	return report
+}
+
+func writeHoursReport(report hoursReport, detail bool) {
+	var filters []string
+	if report.Milestone != "" {
+		filters = append(filters, "マイルストーン: "+report.Milestone)
+	}
+	if report.User != "" {
+		filters = append(filters, "担当者: "+report.User)
+	}
+	if len(filters) > 0 {
+		fmt.Println(labelStyle.Render(strings.Join(filters, " | ")))
+		fmt.Println()
+	}
+
+	nameWidth := lipgloss.Width("担当者")
+	for _, row := range report.Users {
+		nameWidth = max(nameWidth, lipgloss.Width(row.Name))
+	}
+
+	fmt.Println(headerStyle.Render(pad("担当者", nameWidth) + "  " + padLeft("課題", 4) + "  " +
+		padLeft("予定", 8) + "  " + padLeft("実績", 8) + "  " + padLeft("差", 8) + "  " + padLeft("消化率", 6)))
+	for _, row := range append(report.Users, report.Total) {
+		line := pad(row.Name, nameWidth) + "  " + padLeft(strconv.Itoa(row.Issues), 4) + "  " +
+			padLeft(cmdutil.FormatHours(row.Estimated), 8) + "  " + padLeft(cmdutil.FormatHours(row.Actual), 8) + "  " +
+			padLeft(formatDiff(row.Actual-row.Estimated), 8) + "  " + padLeft(formatRatio(row), 6)
+		if row.Estimated > 0 && row.Actual > row.Estimated {
+			line = overStyle.Render(line)
+		}
+		if row.Name == report.Total.Name {
+			line = headerStyle.Render(line)
+		}
+		fmt.Println(line)
+	}
+
+	if !detail {
+		return
+	}
+	fmt.Println()
+	for _, issue := range report.Issues {
+		estimated, actual := "-", "-"
+		if issue.Estimated != nil {
+			estimated = cmdutil.FormatHours(*issue.Estimated)
+		}
+		if issue.Actual != nil {
+			actual = cmdutil.FormatHours(*issue.Actual)
+		}
+		fmt.Printf("%s\t%s\t%s\t%s / %s\t%s\n", issue.IssueKey, issue.Status, issue.Assignee, actual, estimated, issue.Summary)
+	}
+}
+
+// formatDiff formats actual minus estimated hours with a sign.
+func formatDiff(h float64) string {
+	if h > 0 {
+		return "+" + cmdutil.FormatHours(h)
+	}
+	return cmdutil.FormatHours(h)
+}
+
+// formatRatio formats actual hours as a percentage of estimated hours.
+func formatRatio(row hoursRow) string {
+	if row.Estimated == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.0f%%", row.Actual/row.Estimated*100)
+}
+
+// pad pads s with spaces to width display columns.
+func pad(s string, width int) string {
+	return s + strings.Repeat(" ", max(width-lipgloss.Width(s), 0))
+}
+
+// padLeft right-aligns s in width display columns.
+func padLeft(s string, width int) string {
+	return strings.Repeat(" ", max(width-lipgloss.Width(s), 0)) + s
+}
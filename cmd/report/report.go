@@ -0,0 +1,15 @@
+package report
+
+import "github.com/spf13/cobra"
+
+// NewReportCmd returns the report subcommand group.
+func NewReportCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "report",
+		Short: "課題の集計レポート",
+	}
+
+	cmd.AddCommand(newHoursCmd())
+
+	return cmd
+}
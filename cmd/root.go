@@ -1,10 +1,23 @@
 package cmd
 
 import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+
+	blapi "github.com/KimMaru10/bl-cli/cmd/api"
 	"github.com/KimMaru10/bl-cli/cmd/auth"
+	blgit "github.com/KimMaru10/bl-cli/cmd/git"
 	"github.com/KimMaru10/bl-cli/cmd/issue"
 	blmcp "github.com/KimMaru10/bl-cli/cmd/mcp"
 	"github.com/KimMaru10/bl-cli/cmd/project"
+	"github.com/KimMaru10/bl-cli/cmd/release"
+	"github.com/KimMaru10/bl-cli/cmd/report"
+	"github.com/KimMaru10/bl-cli/cmd/timer"
+	"github.com/KimMaru10/bl-cli/cmd/view"
+	"github.com/KimMaru10/bl-cli/internal/cmdutil"
+	"github.com/KimMaru10/bl-cli/internal/config"
 	"github.com/spf13/cobra"
 )
 
@@ -14,16 +27,27 @@ var rootCmd = &cobra.Command{
 }
 
 func init() {
+	cmdutil.AddGlobalFlags(rootCmd)
+	config.PromptPassphrase = cmdutil.PromptPassphrase
+
 	rootCmd.AddCommand(auth.NewAuthCmd())
 	rootCmd.AddCommand(project.NewProjectCmd())
 	rootCmd.AddCommand(issue.NewIssueCmd())
+	rootCmd.AddCommand(blapi.NewAPICmd())
+	rootCmd.AddCommand(blgit.NewGitCmd())
+	rootCmd.AddCommand(release.NewReleaseCmd())
+	rootCmd.AddCommand(report.NewReportCmd())
+	rootCmd.AddCommand(timer.NewTimerCmd())
+	rootCmd.AddCommand(view.NewViewCmd())
+	var mcpOpts blmcp.Options
 	mcpCmd := &cobra.Command{
 		Use:   "mcp",
 		Short: "Claude Desktop 連携（MCP サーバー）",
 		Run: func(cmd *cobra.Command, args []string) {
-			blmcp.Run()
+			blmcp.Run(cmd.Context(), mcpOpts)
 		},
 	}
+	mcpCmd.Flags().BoolVar(&mcpOpts.AllowDelete, "allow-delete", false, "課題とコメントを削除するツールを有効にする")
 	mcpCmd.AddCommand(&cobra.Command{
 		Use:   "setup",
 		Short: "Claude Desktop に Backlog MCP サーバーを登録する",
@@ -39,7 +63,15 @@ func SetVersion(v string) {
 	rootCmd.Version = v
 }
 
-// Execute runs the root command.
+// Execute runs the root command. Pending API requests are cancelled
+// on Ctrl-C. A hint is printed for errors that have one.
 func Execute() error {
-	return rootCmd.Execute()
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	err := rootCmd.ExecuteContext(ctx)
+	if hint := cmdutil.Hint(err); hint != "" {
+		fmt.Fprintln(os.Stderr, "ヒント: "+hint)
+	}
+	return err
 }
@@ -0,0 +1,321 @@
+package timer
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/KimMaru10/bl-cli/internal/cmdutil"
+	"github.com/KimMaru10/bl-cli/internal/timer"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/cobra"
+)
+
+var (
+	successStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("2"))
+	runningStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("4")).Bold(true)
+	labelStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("8"))
+)
+
+// NewTimerCmd returns the timer subcommand group.
+func NewTimerCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "timer",
+		Short: "作業時間をタイマーで計測する",
+		Long: `課題ごとの作業時間を手元のタイマーで計測します。
+
+計測した時間は bl timer commit で課題の実績時間に加算するまで
+設定ディレクトリの timers.json に保存されます。同時に計測できる
+タイマーは 1 つで、別の課題のタイマーを開始すると計測中のタイマーは
+停止します。`,
+	}
+
+	cmd.AddCommand(newStartCmd())
+	cmd.AddCommand(newStopCmd())
+	cmd.AddCommand(newStatusCmd())
+	cmd.AddCommand(newListCmd())
+	cmd.AddCommand(newCommitCmd())
+
+	return cmd
+}
+
+func newStartCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "start [issueKey]",
+		Short: "タイマーを開始する",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			cfg, client, err := cmdutil.LoadConfigAndClient()
+			if err != nil {
+				return err
+			}
+
+			issueKey, err := cmdutil.ResolveIssueKey(cfg, args)
+			if err != nil {
+				return err
+			}
+			issue, err := client.GetIssue(ctx, issueKey)
+			if err != nil {
+				return err
+			}
+
+			store, err := timer.Load()
+			if err != nil {
+				return err
+			}
+			now := time.Now()
+			stopped, err := store.Start(cfg.CurrentName(), issue.IssueKey, now)
+			if err != nil {
+				return err
+			}
+			if err := store.Save(); err != nil {
+				return err
+			}
+
+			if stopped != nil {
+				fmt.Println(labelStyle.Render(fmt.Sprintf("%s のタイマーを停止しました（%s）", stopped.IssueKey, formatDuration(stopped.Duration(now)))))
+			}
+			fmt.Println(successStyle.Render("✔ " + issue.IssueKey + " " + issue.Summary + " のタイマーを開始しました"))
+			return nil
+		},
+	}
+}
+
+func newStopCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "stop",
+		Short: "タイマーを停止する",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			store, err := timer.Load()
+			if err != nil {
+				return err
+			}
+			session, err := store.Stop(time.Now())
+			if err != nil {
+				return err
+			}
+			if err := store.Save(); err != nil {
+				return err
+			}
+
+			pending := timer.Total(store.Pending(session.Space, session.IssueKey))
+			fmt.Println(successStyle.Render(fmt.Sprintf("✔ %s のタイマーを停止しました（%s）", session.IssueKey, formatDuration(session.Duration(session.End)))))
+			fmt.Println(labelStyle.Render(fmt.Sprintf("未反映の合計 %s — bl timer commit %s で実績時間に反映できます", formatDuration(pending), session.IssueKey)))
+			return nil
+		},
+	}
+}
+
+func newStatusCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "status",
+		Short: "計測中のタイマーと未反映の時間を表示する",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := cmdutil.LoadConfig()
+			if err != nil {
+				return err
+			}
+			store, err := timer.Load()
+			if err != nil {
+				return err
+			}
+
+			now := time.Now()
+			if r := store.Running; r != nil {
+				fmt.Println(runningStyle.Render(fmt.Sprintf("● %s 計測中 %s", r.IssueKey, formatDuration(r.Duration(now)))) +
+					labelStyle.Render(" （"+r.Start.Local().Format("15:04")+" から）"))
+			} else {
+				fmt.Println(labelStyle.Render(timer.ErrNotRunning.Error()))
+			}
+
+			totals := pendingByIssue(store.Pending(cfg.CurrentName(), ""))
+			if len(totals) == 0 {
+				return nil
+			}
+			fmt.Println()
+			fmt.Println("未反映の作業時間:")
+			for _, t := range totals {
+				fmt.Printf("  %s\t%s\n", t.issueKey, formatDuration(t.total))
+			}
+			return nil
+		},
+	}
+}
+
+func newListCmd() *cobra.Command {
+	var (
+		all      bool
+		exporter *cmdutil.Exporter
+	)
+
+	cmd := &cobra.Command{
+		Use:   "list [issueKey]",
+		Short: "計測した作業時間の記録を表示する",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := cmdutil.LoadConfig()
+			if err != nil {
+				return err
+			}
+			store, err := timer.Load()
+			if err != nil {
+				return err
+			}
+
+			space := cfg.CurrentName()
+			var sessions []timer.Session
+			for _, s := range store.Sessions {
+				if s.Space != space || (!all && s.Committed) {
+					continue
+				}
+				if len(args) > 0 && s.IssueKey != args[0] {
+					continue
+				}
+				sessions = append(sessions, s)
+			}
+
+			if exporter.Enabled() {
+				if sessions == nil {
+					sessions = []timer.Session{}
+				}
+				return exporter.Write(os.Stdout, sessions)
+			}
+
+			if len(sessions) == 0 {
+				fmt.Println("記録はありません")
+				return nil
+			}
+			for _, s := range sessions {
+				line := fmt.Sprintf("%s  %s-%s  %-8s %s", s.Start.Local().Format("2006-01-02"), s.Start.Local().Format("15:04"), s.End.Local().Format("15:04"),
+					formatDuration(s.Duration(s.End)), s.IssueKey)
+				if s.Committed {
+					line = labelStyle.Render(line + " (反映済み)")
+				}
+				fmt.Println(line)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVarP(&all, "all", "a", false, "実績時間に反映済みの記録も表示する")
+	exporter = cmdutil.AddJSONFlags(cmd, cmdutil.StructFields(timer.Session{}))
+
+	return cmd
+}
+
+func newCommitCmd() *cobra.Command {
+	var (
+		comment string
+		summary bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "commit [issueKey]",
+		Short: "計測した時間を課題の実績時間に反映する",
+		Long: `未反映の作業時間を課題の実績時間に加算します。
+
+課題キーを省略すると、未反映の記録があるすべての課題に反映します。
+--summary を付けると、作業した時間帯の一覧をコメントとして追加します。
+計測中のタイマーは反映されないため、先に bl timer stop してください。`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			cfg, client, err := cmdutil.LoadConfigAndClient()
+			if err != nil {
+				return err
+			}
+			store, err := timer.Load()
+			if err != nil {
+				return err
+			}
+
+			space := cfg.CurrentName()
+			issueKey := ""
+			if len(args) > 0 {
+				issueKey = args[0]
+			}
+			if r := store.Running; r != nil && r.Space == space && (issueKey == "" || r.IssueKey == issueKey) {
+				fmt.Println(labelStyle.Render(r.IssueKey + " のタイマーは計測中のため反映されません"))
+			}
+
+			totals := pendingByIssue(store.Pending(space, issueKey))
+			if len(totals) == 0 {
+				fmt.Println("反映する記録はありません")
+				return nil
+			}
+
+			for _, t := range totals {
+				text := comment
+				if summary {
+					text = strings.TrimSpace(text + "\n\n" + sessionSummary(t))
+				}
+				hours := t.total.Hours()
+				if _, err := cmdutil.LogTime(ctx, client, t.issueKey, hours, text); err != nil {
+					return err
+				}
+				// Save after each issue so a failure part way through does
+				// not add the same time twice on retry.
+				store.MarkCommitted(space, t.issueKey)
+				if err := store.Save(); err != nil {
+					return err
+				}
+				fmt.Println(successStyle.Render(fmt.Sprintf("✔ %s の実績時間に %s を加算しました", t.issueKey, cmdutil.FormatHours(hours))))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&comment, "comment", "", "更新時コメント")
+	cmd.Flags().BoolVar(&summary, "summary", false, "作業した時間帯の一覧をコメントに含める")
+
+	return cmd
+}
+
+// issueTotal is the uncommitted time recorded for one issue.
+type issueTotal struct {
+	issueKey string
+	total    time.Duration
+	sessions []timer.Session
+}
+
+// pendingByIssue groups sessions by issue key, in order of first use.
+func pendingByIssue(sessions []timer.Session) []issueTotal {
+	var totals []issueTotal
+	for _, s := range sessions {
+		i := slices.IndexFunc(totals, func(t issueTotal) bool { return t.issueKey == s.IssueKey })
+		if i < 0 {
+			totals = append(totals, issueTotal{issueKey: s.IssueKey})
+			i = len(totals) - 1
+		}
+		totals[i].total += s.Duration(s.End)
+		totals[i].sessions = append(totals[i].sessions, s)
+	}
+	return totals
+}
+
+// sessionSummary lists the sessions of t for a comment.
+func sessionSummary(t issueTotal) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "作業時間 %s（%d 回）", cmdutil.FormatHours(t.total.Hours()), len(t.sessions))
+	for _, s := range t.sessions {
+		fmt.Fprintf(&b, "\n- %s %s-%s（%s）", s.Start.Local().Format("01/02"), s.Start.Local().Format("15:04"), s.End.Local().Format("15:04"), formatDuration(s.Duration(s.End)))
+	}
+	return b.String()
+}
+
+// formatDuration formats d as hours and minutes, e.g. 1h05m.
+func formatDuration(d time.Duration) string {
+	d = d.Round(time.Minute)
+	h, m := int(d.Hours()), int(d.Minutes())%60
+	if h == 0 {
+		return fmt.Sprintf("%dm", m)
+	}
+	return fmt.Sprintf("%dh%02dm", h, m)
+}
@@ -0,0 +1,271 @@
+package view
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/KimMaru10/bl-cli/cmd/issue"
+	"github.com/KimMaru10/bl-cli/internal/cmdutil"
+	"github.com/KimMaru10/bl-cli/internal/config"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/cobra"
+	"go.yaml.in/yaml/v3"
+)
+
+var (
+	successStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("2"))
+	headerStyle  = lipgloss.NewStyle().Bold(true)
+	labelStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("8"))
+)
+
+// NewViewCmd returns the view subcommand group.
+func NewViewCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "view",
+		Short: "保存した課題一覧のビューを管理する",
+		Long: `bl issue list --save NAME で保存したビューを管理します。
+
+ビューは設定ファイルに保存されます。bl view export --local で
+リポジトリの ` + config.LocalConfigFile + ` に書き出すと、チームで共有できます。
+同じ名前のビューがある場合は ` + config.LocalConfigFile + ` のものが優先されます。`,
+	}
+
+	cmd.AddCommand(newRunCmd())
+	cmd.AddCommand(newListCmd())
+	cmd.AddCommand(newDeleteCmd())
+	cmd.AddCommand(newExportCmd())
+
+	return cmd
+}
+
+func newRunCmd() *cobra.Command {
+	cmd := issue.NewListCmd()
+	cmd.Use = "run <name> [query]"
+	cmd.Short = "ビューで課題一覧を表示する"
+	cmd.Long = `保存したビューで課題一覧を表示します。bl issue list @NAME と同じです。
+
+ビュー名に続けて指定した条件やフラグはビューに追加されます。`
+	cmd.Example = `  bl view run my-bugs
+  bl view run my-bugs priority:高 --limit 50`
+	cmd.Args = cobra.MinimumNArgs(1)
+
+	list := cmd.RunE
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		return list(cmd, append([]string{"@" + args[0]}, args[1:]...))
+	}
+	return cmd
+}
+
+// viewEntry is a view as listed by bl view list, for --json, --jq and
+// --template.
+type viewEntry struct {
+	Name   string `json:"name"`
+	Source string `json:"source"`
+	config.View
+}
+
+func newListCmd() *cobra.Command {
+	var exporter *cmdutil.Exporter
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "ビューの一覧を表示する",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := cmdutil.LoadConfig()
+			if err != nil {
+				return err
+			}
+
+			entries := []viewEntry{}
+			for _, name := range sortedNames(cfg.Local().Views) {
+				entries = append(entries, viewEntry{Name: name, Source: config.LocalConfigFile, View: cfg.Local().Views[name]})
+			}
+			for _, name := range sortedNames(cfg.Views) {
+				if _, ok := cfg.Local().Views[name]; ok {
+					continue
+				}
+				entries = append(entries, viewEntry{Name: name, Source: "config", View: cfg.Views[name]})
+			}
+
+			if exporter.Enabled() {
+				return exporter.Write(os.Stdout, entries)
+			}
+
+			if len(entries) == 0 {
+				fmt.Println("ビューはありません（bl issue list --save NAME で保存できます）")
+				return nil
+			}
+
+			fmt.Printf("%s\t%s\t%s\t%s\n",
+				headerStyle.Render("NAME"),
+				headerStyle.Render("PROJECT"),
+				headerStyle.Render("QUERY"),
+				headerStyle.Render("SOURCE"),
+			)
+			for _, e := range entries {
+				query := e.Query
+				for _, f := range e.Fields {
+					query = strings.TrimSpace(query + " --field " + f)
+				}
+				project := e.Project
+				if project == "" {
+					project = "-"
+				}
+				fmt.Printf("%s\t%s\t%s\t%s\n", e.Name, project, query, labelStyle.Render(e.Source))
+			}
+			return nil
+		},
+	}
+
+	exporter = cmdutil.AddJSONFlags(cmd, cmdutil.StructFields(viewEntry{}))
+
+	return cmd
+}
+
+func newDeleteCmd() *cobra.Command {
+	var local bool
+
+	cmd := &cobra.Command{
+		Use:   "delete <name>",
+		Short: "ビューを削除する",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := args[0]
+
+			if local {
+				p, err := config.FindLocalConfig()
+				if err != nil {
+					return err
+				}
+				if p == "" {
+					return fmt.Errorf("%s が見つかりません", config.LocalConfigFile)
+				}
+				lc, err := config.ReadLocal(p)
+				if err != nil {
+					return err
+				}
+				if _, ok := lc.Views[name]; !ok {
+					return fmt.Errorf("ビュー '%s' は %s にありません", name, p)
+				}
+				delete(lc.Views, name)
+				if len(lc.Views) == 0 {
+					lc.Views = nil
+				}
+				if err := config.SaveLocal(p, lc); err != nil {
+					return err
+				}
+				fmt.Println(successStyle.Render("✔ " + p + " からビュー '" + name + "' を削除しました"))
+				return nil
+			}
+
+			cfg, err := cmdutil.LoadConfig()
+			if err != nil {
+				return err
+			}
+			if _, ok := cfg.Views[name]; !ok {
+				if _, ok := cfg.Local().Views[name]; ok {
+					return fmt.Errorf("ビュー '%s' は %s にあります（--local で削除できます）", name, config.LocalConfigFile)
+				}
+				return fmt.Errorf("ビュー '%s' が見つかりません", name)
+			}
+			delete(cfg.Views, name)
+			if len(cfg.Views) == 0 {
+				cfg.Views = nil
+			}
+			if err := config.Save(cfg); err != nil {
+				return err
+			}
+			fmt.Println(successStyle.Render("✔ ビュー '" + name + "' を削除しました"))
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&local, "local", false, "このリポジトリの "+config.LocalConfigFile+" から削除する")
+
+	return cmd
+}
+
+func newExportCmd() *cobra.Command {
+	var local bool
+
+	cmd := &cobra.Command{
+		Use:   "export [name...]",
+		Short: "ビューを YAML で書き出す",
+		Long: `設定ファイルのビューを ` + config.LocalConfigFile + ` の views: の形式で出力します。
+
+名前を省略するとすべてのビューを書き出します。--local を付けると
+リポジトリの ` + config.LocalConfigFile + ` に追加し、同じ名前のビューは上書きします。`,
+		Example: `  bl view export my-bugs overdue
+  bl view export --local`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := cmdutil.LoadConfig()
+			if err != nil {
+				return err
+			}
+
+			names := args
+			if len(names) == 0 {
+				names = sortedNames(cfg.Views)
+			}
+			if len(names) == 0 {
+				return fmt.Errorf("書き出すビューがありません（bl issue list --save NAME で保存できます）")
+			}
+			views := map[string]config.View{}
+			for _, name := range names {
+				v, ok := cfg.View(name)
+				if !ok {
+					return fmt.Errorf("ビュー '%s' が見つかりません", name)
+				}
+				views[name] = v
+			}
+
+			if !local {
+				data, err := yaml.Marshal(struct {
+					Views map[string]config.View `yaml:"views"`
+				}{views})
+				if err != nil {
+					return fmt.Errorf("ビューのシリアライズに失敗しました: %w", err)
+				}
+				_, err = os.Stdout.Write(data)
+				return err
+			}
+
+			p, err := config.LocalConfigPath()
+			if err != nil {
+				return err
+			}
+			lc, err := config.ReadLocal(p)
+			if err != nil {
+				return err
+			}
+			if lc.Views == nil {
+				lc.Views = map[string]config.View{}
+			}
+			for name, v := range views {
+				lc.Views[name] = v
+			}
+			if err := config.SaveLocal(p, lc); err != nil {
+				return err
+			}
+			fmt.Println(successStyle.Render(fmt.Sprintf("✔ %s にビューを書き出しました（%s）", p, strings.Join(names, ", "))))
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&local, "local", false, "このリポジトリの "+config.LocalConfigFile+" に書き出す")
+
+	return cmd
+}
+
+// sortedNames returns the view names in views, sorted.
+func sortedNames(views map[string]config.View) []string {
+	names := make([]string, 0, len(views))
+	for name := range views {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
@@ -0,0 +1,80 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"strconv"
+)
+
+// UploadAttachment uploads a file to the space so that it can be linked
+// to an issue or comment through its ID. Uploaded files that are not
+// linked are discarded by Backlog after a while.
+func (c *Client) UploadAttachment(ctx context.Context, name string, r io.Reader) (*Attachment, error) {
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+	part, err := w.CreateFormFile("file", name)
+	if err != nil {
+		return nil, fmt.Errorf("添付ファイルの準備に失敗しました: %w", err)
+	}
+	if _, err := io.Copy(part, r); err != nil {
+		return nil, fmt.Errorf("%s の読み込みに失敗しました: %w", name, err)
+	}
+	if err := w.Close(); err != nil {
+		return nil, fmt.Errorf("添付ファイルの準備に失敗しました: %w", err)
+	}
+
+	data, err := c.do(ctx, &request{
+		method:      http.MethodPost,
+		path:        "/space/attachment",
+		body:        body.Bytes(),
+		contentType: w.FormDataContentType(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s のアップロードに失敗しました: %w", name, err)
+	}
+	var attachment Attachment
+	if err := json.Unmarshal(data, &attachment); err != nil {
+		return nil, fmt.Errorf("添付ファイルの解析に失敗しました: %w", err)
+	}
+	return &attachment, nil
+}
+
+// GetIssueAttachments returns the files attached to an issue.
+func (c *Client) GetIssueAttachments(ctx context.Context, issueIDOrKey string) ([]Attachment, error) {
+	data, err := c.get(ctx, "/issues/"+issueIDOrKey+"/attachments", nil)
+	if err != nil {
+		return nil, fmt.Errorf("添付ファイル一覧の取得に失敗しました: %w", err)
+	}
+	var attachments []Attachment
+	if err := json.Unmarshal(data, &attachments); err != nil {
+		return nil, fmt.Errorf("添付ファイル一覧の解析に失敗しました: %w", err)
+	}
+	return attachments, nil
+}
+
+// DownloadIssueAttachment returns the content of a file attached to an issue.
+func (c *Client) DownloadIssueAttachment(ctx context.Context, issueIDOrKey string, attachmentID int) ([]byte, error) {
+	data, err := c.get(ctx, "/issues/"+issueIDOrKey+"/attachments/"+strconv.Itoa(attachmentID), nil)
+	if err != nil {
+		return nil, fmt.Errorf("添付ファイルのダウンロードに失敗しました: %w", err)
+	}
+	return data, nil
+}
+
+// DeleteIssueAttachment removes a file from an issue and returns it.
+func (c *Client) DeleteIssueAttachment(ctx context.Context, issueIDOrKey string, attachmentID int) (*Attachment, error) {
+	data, err := c.delete(ctx, "/issues/"+issueIDOrKey+"/attachments/"+strconv.Itoa(attachmentID))
+	if err != nil {
+		return nil, fmt.Errorf("添付ファイルの削除に失敗しました: %w", err)
+	}
+	var attachment Attachment
+	if err := json.Unmarshal(data, &attachment); err != nil {
+		return nil, fmt.Errorf("添付ファイルの解析に失敗しました: %w", err)
+	}
+	return &attachment, nil
+}
@@ -1,100 +1,332 @@
 package api
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"math/rand/v2"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+// DefaultTimeout is the per-request timeout used when none is configured.
+const DefaultTimeout = 30 * time.Second
+
+// DefaultMaxRetries is the number of retries used when none is configured.
+const DefaultMaxRetries = 3
+
+// DefaultMaxRateLimitWait is the longest the client waits for the rate
+// limit to reset when none is configured. A 429 whose reset is further
+// away fails at once instead of blocking silently.
+const DefaultMaxRateLimitWait = time.Minute
+
+const (
+	retryBaseDelay = 500 * time.Millisecond
+	retryMaxDelay  = 10 * time.Second
 )
 
 // Client is the Backlog API client.
 type Client struct {
-	baseURL    string
-	apiKey     string
-	httpClient *http.Client
+	baseURL     string
+	apiKey      string
+	tokenSource TokenSource
+	timeout     time.Duration
+	maxRetries  int
+	maxWait     time.Duration
+	debug       io.Writer
+	httpClient  *http.Client
+
+	mu        sync.Mutex
+	rateLimit *RateLimit
+}
+
+// Option configures a Client.
+type Option func(*Client)
+
+// WithTimeout sets the timeout applied to each request, including
+// reading the response body. A zero or negative value disables it.
+func WithTimeout(d time.Duration) Option {
+	return func(c *Client) {
+		c.timeout = d
+	}
+}
+
+// TokenSource supplies OAuth 2.0 tokens. ctx is the context of the
+// request being authenticated, so a token refresh is bounded by the same
+// timeout and cancelled with it.
+type TokenSource interface {
+	Token(ctx context.Context) (*oauth2.Token, error)
+}
+
+// WithTokenSource authenticates requests with an OAuth 2.0 bearer token
+// instead of the apiKey query parameter.
+func WithTokenSource(ts TokenSource) Option {
+	return func(c *Client) {
+		c.tokenSource = ts
+	}
+}
+
+// WithMaxRetries sets how many times a failed request is retried.
+func WithMaxRetries(n int) Option {
+	return func(c *Client) {
+		c.maxRetries = n
+	}
+}
+
+// WithMaxRateLimitWait sets the longest wait for the rate limit to reset
+// before a rate-limited request is retried.
+func WithMaxRateLimitWait(d time.Duration) Option {
+	return func(c *Client) {
+		c.maxWait = d
+	}
+}
+
+// WithDebug makes the client log retries and rate-limit headers to w.
+func WithDebug(w io.Writer) Option {
+	return func(c *Client) {
+		c.debug = w
+	}
 }
 
 // NewClient creates a new Backlog API client.
-func NewClient(spaceURL, apiKey string) *Client {
+func NewClient(spaceURL, apiKey string, opts ...Option) *Client {
 	base := strings.TrimRight(spaceURL, "/") + "/api/v2"
-	return &Client{
+	c := &Client{
 		baseURL:    base,
 		apiKey:     apiKey,
+		timeout:    DefaultTimeout,
+		maxRetries: DefaultMaxRetries,
+		maxWait:    DefaultMaxRateLimitWait,
 		httpClient: &http.Client{},
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
-func (c *Client) do(method, path string, params url.Values, body io.Reader) (*http.Response, error) {
-	u := c.baseURL + path
+// RateLimit returns the rate-limit state reported by the most recent
+// response, or nil if no response carried rate-limit headers.
+func (c *Client) RateLimit() *RateLimit {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.rateLimit == nil {
+		return nil
+	}
+	rl := *c.rateLimit
+	return &rl
+}
 
-	if params == nil {
-		params = url.Values{}
+// request describes a single API call.
+// Without a body, params are sent as the query string for GET and as a
+// urlencoded form otherwise. With a body, params go to the query string.
+type request struct {
+	method      string
+	path        string
+	params      url.Values
+	body        []byte
+	contentType string
+}
+
+// do sends a request, retrying idempotent requests on transient failures
+// and any request rejected by the rate limiter.
+func (c *Client) do(ctx context.Context, r *request) ([]byte, error) {
+	switch r.method {
+	case http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+	default:
+		return nil, fmt.Errorf("サポートされていないHTTPメソッドです: %s", r.method)
 	}
-	params.Set("apiKey", c.apiKey)
 
-	var req *http.Request
-	var err error
+	for attempt := 0; ; attempt++ {
+		body, status, header, err := c.send(ctx, r)
+		if err != nil && ctx.Err() != nil {
+			return nil, err
+		}
 
-	switch method {
-	case http.MethodGet:
-		u += "?" + params.Encode()
-		req, err = http.NewRequest(method, u, nil)
-	case http.MethodPost, http.MethodPatch:
-		req, err = http.NewRequest(method, u+"?apiKey="+url.QueryEscape(c.apiKey), strings.NewReader(params.Encode()))
-		if err == nil {
-			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		var wait time.Duration
+		switch {
+		case status == http.StatusTooManyRequests:
+			// The request was rejected, so retrying is safe for any method.
+			wait = c.untilReset(header, attempt)
+			if wait > c.maxWait {
+				c.debugf("%s %s: レート制限のリセットまで %s かかるため中止します", r.method, r.path, wait.Round(time.Second))
+				return nil, parseError(status, body)
+			}
+		case r.method == http.MethodGet && (err != nil || status >= 500):
+			wait = backoff(attempt)
+		}
+
+		if wait == 0 || attempt >= c.maxRetries {
+			if err != nil {
+				return nil, err
+			}
+			if status >= 400 {
+				return nil, parseError(status, body)
+			}
+			return body, nil
+		}
+
+		c.debugf("%s %s: リトライします（%d/%d、%s 後）", r.method, r.path, attempt+1, c.maxRetries, wait.Round(time.Millisecond))
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+}
+
+// send performs a single HTTP round trip and reads the whole body.
+func (c *Client) send(ctx context.Context, r *request) ([]byte, int, http.Header, error) {
+	if c.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+	}
+
+	query := url.Values{}
+	body := r.body
+	contentType := r.contentType
+	if body == nil && r.method != http.MethodGet {
+		body = []byte(r.params.Encode())
+		contentType = "application/x-www-form-urlencoded"
+	} else {
+		for k, v := range r.params {
+			query[k] = v
 		}
-	default:
-		return nil, fmt.Errorf("サポートされていないHTTPメソッドです: %s", method)
 	}
+	var authorization string
+	if c.tokenSource != nil {
+		tok, err := c.tokenSource.Token(ctx)
+		if err != nil {
+			return nil, 0, nil, fmt.Errorf("OAuth トークンの取得に失敗しました: %w", err)
+		}
+		authorization = "Bearer " + tok.AccessToken
+	} else {
+		query.Set("apiKey", c.apiKey)
+	}
+
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, r.method, c.baseURL+r.path+"?"+query.Encode(), reader)
 	if err != nil {
-		return nil, fmt.Errorf("リクエストの作成に失敗しました: %w", err)
+		return nil, 0, nil, fmt.Errorf("リクエストの作成に失敗しました: %w", err)
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	if authorization != "" {
+		req.Header.Set("Authorization", authorization)
 	}
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("リクエストの送信に失敗しました: %w", err)
+		// Drop the URL from the message so the API key is not printed
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return nil, 0, nil, fmt.Errorf("リクエストの送信に失敗しました: %w", err)
 	}
+	defer resp.Body.Close()
 
-	if resp.StatusCode >= 400 {
-		defer resp.Body.Close()
-		respBody, _ := io.ReadAll(resp.Body)
+	c.recordRateLimit(resp.Header)
 
-		var errResp BacklogErrorResponse
-		if json.Unmarshal(respBody, &errResp) == nil && len(errResp.Errors) > 0 {
-			return nil, fmt.Errorf("Backlog API エラー: %s (code: %d)", errResp.Errors[0].Message, errResp.Errors[0].Code)
-		}
-		return nil, fmt.Errorf("Backlog API エラー: ステータスコード %d", resp.StatusCode)
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, 0, nil, fmt.Errorf("レスポンスの読み込みに失敗しました: %w", err)
 	}
+	return respBody, resp.StatusCode, resp.Header, nil
+}
 
-	return resp, nil
+func parseError(status int, body []byte) error {
+	apiErr := &Error{StatusCode: status}
+	var errResp BacklogErrorResponse
+	if json.Unmarshal(body, &errResp) == nil {
+		apiErr.Errors = errResp.Errors
+	}
+	return apiErr
 }
 
-func (c *Client) get(path string, params url.Values) ([]byte, error) {
-	resp, err := c.do(http.MethodGet, path, params, nil)
-	if err != nil {
-		return nil, err
+// recordRateLimit stores the X-RateLimit-* headers of a response.
+func (c *Client) recordRateLimit(h http.Header) {
+	limit, err1 := strconv.Atoi(h.Get("X-RateLimit-Limit"))
+	remaining, err2 := strconv.Atoi(h.Get("X-RateLimit-Remaining"))
+	reset, err3 := strconv.ParseInt(h.Get("X-RateLimit-Reset"), 10, 64)
+	if err := errors.Join(err1, err2, err3); err != nil {
+		return
 	}
-	defer resp.Body.Close()
-	return io.ReadAll(resp.Body)
+
+	rl := &RateLimit{Limit: limit, Remaining: remaining, Reset: reset}
+	c.mu.Lock()
+	c.rateLimit = rl
+	c.mu.Unlock()
+
+	c.debugf("レート制限: 残り %d/%d（%s にリセット）", remaining, limit, rl.ResetTime().Format(time.TimeOnly))
 }
 
-func (c *Client) post(path string, values url.Values) ([]byte, error) {
-	resp, err := c.do(http.MethodPost, path, values, nil)
+// untilReset returns how long to wait before retrying a 429 response,
+// falling back to exponential backoff when the reset time is unknown.
+func (c *Client) untilReset(h http.Header, attempt int) time.Duration {
+	reset, err := strconv.ParseInt(h.Get("X-RateLimit-Reset"), 10, 64)
 	if err != nil {
-		return nil, err
+		return backoff(attempt)
 	}
-	defer resp.Body.Close()
-	return io.ReadAll(resp.Body)
+	wait := time.Until(time.Unix(reset, 0))
+	if wait <= 0 {
+		return backoff(attempt)
+	}
+	// Add a little jitter so concurrent callers do not retry in lockstep.
+	return wait + time.Duration(rand.Int64N(int64(time.Second)))
 }
 
-func (c *Client) patch(path string, values url.Values) ([]byte, error) {
-	resp, err := c.do(http.MethodPatch, path, values, nil)
-	if err != nil {
-		return nil, err
+// backoff returns a jittered exponential delay for the given attempt.
+func backoff(attempt int) time.Duration {
+	d := retryBaseDelay << attempt
+	if d <= 0 || d > retryMaxDelay {
+		d = retryMaxDelay
 	}
-	defer resp.Body.Close()
-	return io.ReadAll(resp.Body)
+	// Full range [d/2, d*3/2)
+	return d/2 + time.Duration(rand.Int64N(int64(d)))
+}
+
+func (c *Client) debugf(format string, args ...any) {
+	if c.debug == nil {
+		return
+	}
+	fmt.Fprintf(c.debug, "[bl] "+format+"\n", args...)
+}
+
+// Raw sends a request to an arbitrary API path relative to /api/v2 and
+// returns the response body. If body is nil, params are sent as the query
+// string for GET and as a urlencoded form otherwise; if body is given, it
+// is sent as-is with contentType and params go to the query string.
+func (c *Client) Raw(ctx context.Context, method, path string, params url.Values, body []byte, contentType string) ([]byte, error) {
+	return c.do(ctx, &request{method: method, path: path, params: params, body: body, contentType: contentType})
+}
+
+func (c *Client) get(ctx context.Context, path string, params url.Values) ([]byte, error) {
+	return c.do(ctx, &request{method: http.MethodGet, path: path, params: params})
+}
+
+func (c *Client) post(ctx context.Context, path string, values url.Values) ([]byte, error) {
+	return c.do(ctx, &request{method: http.MethodPost, path: path, params: values})
+}
+
+func (c *Client) patch(ctx context.Context, path string, values url.Values) ([]byte, error) {
+	return c.do(ctx, &request{method: http.MethodPatch, path: path, params: values})
+}
+
+func (c *Client) delete(ctx context.Context, path string) ([]byte, error) {
+	return c.do(ctx, &request{method: http.MethodDelete, path: path})
 }
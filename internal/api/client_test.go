@@ -0,0 +1,138 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newTestClient starts a server that answers with the given statuses in
+// turn and repeats the last one. It returns the client and a request counter.
+func newTestClient(t *testing.T, header func(http.Header), statuses ...int) (*Client, *atomic.Int32) {
+	t.Helper()
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := int(calls.Add(1))
+		status := statuses[min(n, len(statuses))-1]
+		if header != nil {
+			header(w.Header())
+		}
+		w.WriteHeader(status)
+		if status >= 400 {
+			w.Write([]byte(`{"errors":[{"message":"error","code":0}]}`))
+			return
+		}
+		w.Write([]byte(`{"ok":true}`))
+	}))
+	t.Cleanup(srv.Close)
+	return NewClient(srv.URL, "key"), &calls
+}
+
+func resetIn(d time.Duration) func(http.Header) {
+	return func(h http.Header) {
+		h.Set("X-RateLimit-Limit", "150")
+		h.Set("X-RateLimit-Remaining", "0")
+		h.Set("X-RateLimit-Reset", strconv.FormatInt(time.Now().Add(d).Unix(), 10))
+	}
+}
+
+func TestClientRetriesGetOnServerError(t *testing.T) {
+	c, calls := newTestClient(t, nil, http.StatusBadGateway, http.StatusOK)
+	body, err := c.get(context.Background(), "/space", nil)
+	if err != nil {
+		t.Fatalf("get() error = %v", err)
+	}
+	if string(body) != `{"ok":true}` || calls.Load() != 2 {
+		t.Errorf("get() = %s after %d requests, want the second response", body, calls.Load())
+	}
+}
+
+func TestClientDoesNotRetryPostOnServerError(t *testing.T) {
+	c, calls := newTestClient(t, nil, http.StatusInternalServerError, http.StatusOK)
+	_, err := c.post(context.Background(), "/issues", nil)
+	var apiErr *Error
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusInternalServerError {
+		t.Fatalf("post() error = %v, want a 500 *Error", err)
+	}
+	if calls.Load() != 1 {
+		t.Errorf("post() sent %d requests, want 1", calls.Load())
+	}
+}
+
+func TestClientMaxRetries(t *testing.T) {
+	c, calls := newTestClient(t, nil, http.StatusServiceUnavailable)
+	WithMaxRetries(1)(c)
+	if _, err := c.get(context.Background(), "/space", nil); err == nil {
+		t.Fatal("get() succeeded, want error")
+	}
+	if calls.Load() != 2 {
+		t.Errorf("get() sent %d requests, want 2", calls.Load())
+	}
+
+	calls.Store(0)
+	WithMaxRetries(0)(c)
+	if _, err := c.get(context.Background(), "/space", nil); err == nil {
+		t.Fatal("get() succeeded, want error")
+	}
+	if calls.Load() != 1 {
+		t.Errorf("get() without retries sent %d requests, want 1", calls.Load())
+	}
+}
+
+func TestClientWaitsForRateLimitReset(t *testing.T) {
+	c, calls := newTestClient(t, resetIn(time.Second), http.StatusTooManyRequests, http.StatusOK)
+	// A rejected POST is retried too.
+	if _, err := c.post(context.Background(), "/issues", nil); err != nil {
+		t.Fatalf("post() error = %v", err)
+	}
+	if calls.Load() != 2 {
+		t.Errorf("post() sent %d requests, want 2", calls.Load())
+	}
+
+	rl := c.RateLimit()
+	if rl == nil || rl.Limit != 150 || rl.Remaining != 0 || rl.Reset == 0 {
+		t.Errorf("RateLimit() = %+v, want limit 150, remaining 0", rl)
+	}
+}
+
+func TestClientRateLimitWaitCap(t *testing.T) {
+	c, calls := newTestClient(t, resetIn(10*time.Minute), http.StatusTooManyRequests, http.StatusOK)
+	start := time.Now()
+	_, err := c.get(context.Background(), "/space", nil)
+	if !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("get() error = %v, want ErrRateLimited", err)
+	}
+	if calls.Load() != 1 || time.Since(start) > 5*time.Second {
+		t.Errorf("get() sent %d requests in %s, want 1 without waiting", calls.Load(), time.Since(start))
+	}
+}
+
+func TestClientCancelDuringWait(t *testing.T) {
+	c, calls := newTestClient(t, resetIn(30*time.Second), http.StatusTooManyRequests)
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := c.get(ctx, "/space", nil)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("get() error = %v, want context.DeadlineExceeded", err)
+	}
+	if calls.Load() != 1 || time.Since(start) > 5*time.Second {
+		t.Errorf("get() sent %d requests in %s, want 1 and an early return", calls.Load(), time.Since(start))
+	}
+}
+
+func TestClientRateLimitHeaders(t *testing.T) {
+	c, _ := newTestClient(t, nil, http.StatusOK)
+	if _, err := c.get(context.Background(), "/space", nil); err != nil {
+		t.Fatal(err)
+	}
+	if rl := c.RateLimit(); rl != nil {
+		t.Errorf("RateLimit() without headers = %+v, want nil", rl)
+	}
+}
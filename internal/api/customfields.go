@@ -0,0 +1,181 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Custom field types, as reported in CustomField.TypeID.
+const (
+	CustomFieldText         = 1
+	CustomFieldTextArea     = 2
+	CustomFieldNumber       = 3
+	CustomFieldDate         = 4
+	CustomFieldSingleList   = 5
+	CustomFieldMultipleList = 6
+	CustomFieldCheckBox     = 7
+	CustomFieldRadio        = 8
+)
+
+// CustomField is the definition of a project custom field.
+type CustomField struct {
+	ID                   int               `json:"id"`
+	TypeID               int               `json:"typeId"`
+	Name                 string            `json:"name"`
+	Description          string            `json:"description"`
+	Required             bool              `json:"required"`
+	ApplicableIssueTypes []int             `json:"applicableIssueTypes"`
+	Items                []CustomFieldItem `json:"items,omitempty"`
+}
+
+// CustomFieldItem is a choice of a list, check box or radio field.
+type CustomFieldItem struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// IsList reports whether values of the field are chosen from Items.
+func (f CustomField) IsList() bool {
+	switch f.TypeID {
+	case CustomFieldSingleList, CustomFieldMultipleList, CustomFieldCheckBox, CustomFieldRadio:
+		return true
+	}
+	return false
+}
+
+// IsMultiple reports whether the field accepts several items.
+func (f CustomField) IsMultiple() bool {
+	return f.TypeID == CustomFieldMultipleList || f.TypeID == CustomFieldCheckBox
+}
+
+// AppliesTo reports whether the field is used by the given issue type.
+func (f CustomField) AppliesTo(issueTypeID int) bool {
+	return len(f.ApplicableIssueTypes) == 0 || slices.Contains(f.ApplicableIssueTypes, issueTypeID)
+}
+
+// CustomFieldValue is the value of a custom field on an issue. Value is
+// kept as raw JSON because its shape depends on the field type; use the
+// typed accessors to read it.
+type CustomFieldValue struct {
+	ID          int             `json:"id"`
+	FieldTypeID int             `json:"fieldTypeId"`
+	Name        string          `json:"name"`
+	Value       json.RawMessage `json:"value"`
+	OtherValue  string          `json:"otherValue,omitempty"`
+}
+
+// Text returns the value of a text, text area or date field.
+func (v CustomFieldValue) Text() string {
+	var s string
+	if json.Unmarshal(v.Value, &s) == nil {
+		return s
+	}
+	return ""
+}
+
+// Number returns the value of a number field.
+func (v CustomFieldValue) Number() (float64, bool) {
+	var n float64
+	if json.Unmarshal(v.Value, &n) == nil && string(v.Value) != "null" {
+		return n, true
+	}
+	return 0, false
+}
+
+// Items returns the chosen items of a list, check box or radio field.
+func (v CustomFieldValue) Items() []CustomFieldItem {
+	var items []CustomFieldItem
+	if json.Unmarshal(v.Value, &items) == nil {
+		return items
+	}
+	var item *CustomFieldItem
+	if json.Unmarshal(v.Value, &item) == nil && item != nil {
+		return []CustomFieldItem{*item}
+	}
+	return nil
+}
+
+// String formats the value for display. It is empty when unset.
+func (v CustomFieldValue) String() string {
+	switch v.FieldTypeID {
+	case CustomFieldNumber:
+		if n, ok := v.Number(); ok {
+			return strconv.FormatFloat(n, 'f', -1, 64)
+		}
+		return ""
+	case CustomFieldSingleList, CustomFieldMultipleList, CustomFieldCheckBox, CustomFieldRadio:
+		var names []string
+		for _, item := range v.Items() {
+			names = append(names, item.Name)
+		}
+		if v.OtherValue != "" {
+			names = append(names, v.OtherValue)
+		}
+		return strings.Join(names, ", ")
+	default:
+		return v.Text()
+	}
+}
+
+// CustomFieldParams holds custom field values to set, keyed by field ID.
+// Text, number and date fields take a single value and list fields take
+// item IDs. An empty slice clears the field.
+type CustomFieldParams map[int][]string
+
+func (p CustomFieldParams) encode(params url.Values) {
+	for id, values := range p {
+		key := "customField_" + strconv.Itoa(id)
+		if len(values) == 0 {
+			params.Set(key, "")
+			continue
+		}
+		for _, v := range values {
+			params.Add(key, v)
+		}
+	}
+}
+
+// CustomFieldFilter narrows GetIssues by a custom field. Keyword applies
+// to text fields, Min and Max to number and date fields and ItemIDs to
+// list fields.
+type CustomFieldFilter struct {
+	ID      int
+	Keyword string
+	Min     string
+	Max     string
+	ItemIDs []int
+}
+
+func (f CustomFieldFilter) encode(params url.Values) {
+	key := "customField_" + strconv.Itoa(f.ID)
+	if f.Keyword != "" {
+		params.Set(key, f.Keyword)
+	}
+	if f.Min != "" {
+		params.Set(key+"_min", f.Min)
+	}
+	if f.Max != "" {
+		params.Set(key+"_max", f.Max)
+	}
+	for _, id := range f.ItemIDs {
+		params.Add(key+"[]", strconv.Itoa(id))
+	}
+}
+
+// GetCustomFields returns the custom field definitions of a project.
+func (c *Client) GetCustomFields(ctx context.Context, projectIDOrKey string) ([]CustomField, error) {
+	data, err := c.get(ctx, "/projects/"+projectIDOrKey+"/customFields", nil)
+	if err != nil {
+		return nil, fmt.Errorf("カスタム属性一覧の取得に失敗しました: %w", err)
+	}
+	var fields []CustomField
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, fmt.Errorf("カスタム属性一覧の解析に失敗しました: %w", err)
+	}
+	return fields, nil
+}
@@ -0,0 +1,83 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Backlog API error codes.
+// See https://developer.nulab.com/docs/backlog/error-response/
+const (
+	CodeInternalError          = 1
+	CodeLicenceError           = 2
+	CodeLicenceExpiredError    = 3
+	CodeAccessDeniedError      = 4
+	CodeUnauthorizedOperation  = 5
+	CodeNoResourceError        = 6
+	CodeInvalidRequestError    = 7
+	CodeSpaceOverCapacityError = 8
+	CodeResourceOverflowError  = 9
+	CodeTooLargeFileError      = 10
+	CodeAuthenticationError    = 11
+	CodeRequiredMFAError       = 12
+	CodeTooManyRequestsError   = 13
+)
+
+// Sentinel errors matched by *Error via errors.Is.
+var (
+	ErrUnauthorized   = errors.New("認証に失敗しました")
+	ErrForbidden      = errors.New("権限がありません")
+	ErrNotFound       = errors.New("リソースが見つかりません")
+	ErrInvalidRequest = errors.New("リクエストが不正です")
+	ErrRateLimited    = errors.New("レート制限に達しました")
+)
+
+// Error is returned when the Backlog API responds with an error status.
+type Error struct {
+	StatusCode int
+	Errors     []BacklogError
+}
+
+func (e *Error) Error() string {
+	if len(e.Errors) == 0 {
+		return fmt.Sprintf("Backlog API エラー: ステータスコード %d", e.StatusCode)
+	}
+	msgs := make([]string, len(e.Errors))
+	for i, be := range e.Errors {
+		msgs[i] = fmt.Sprintf("%s (code: %d)", be.Message, be.Code)
+		if be.MoreInfo != "" {
+			msgs[i] += " " + be.MoreInfo
+		}
+	}
+	return "Backlog API エラー: " + strings.Join(msgs, "; ")
+}
+
+// Is reports whether the error matches one of the sentinel errors,
+// judged by HTTP status or Backlog error code.
+func (e *Error) Is(target error) bool {
+	switch target {
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized || e.HasCode(CodeAuthenticationError)
+	case ErrForbidden:
+		return e.StatusCode == http.StatusForbidden || e.HasCode(CodeUnauthorizedOperation)
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound || e.HasCode(CodeNoResourceError)
+	case ErrInvalidRequest:
+		return e.StatusCode == http.StatusBadRequest || e.HasCode(CodeInvalidRequestError)
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests || e.HasCode(CodeTooManyRequestsError)
+	}
+	return false
+}
+
+// HasCode reports whether any of the Backlog errors has the given code.
+func (e *Error) HasCode(code int) bool {
+	for _, be := range e.Errors {
+		if be.Code == code {
+			return true
+		}
+	}
+	return false
+}
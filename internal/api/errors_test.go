@@ -0,0 +1,72 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestParseError(t *testing.T) {
+	sentinels := []error{ErrUnauthorized, ErrForbidden, ErrNotFound, ErrInvalidRequest, ErrRateLimited}
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		want    []error // every sentinel matched
+		wantMsg string
+	}{
+		{"401", 401, "", []error{ErrUnauthorized}, "ステータスコード 401"},
+		{"403", 403, "", []error{ErrForbidden}, "ステータスコード 403"},
+		{"404", 404, "", []error{ErrNotFound}, "ステータスコード 404"},
+		{"400", 400, "", []error{ErrInvalidRequest}, "ステータスコード 400"},
+		{"429", 429, "", []error{ErrRateLimited}, "ステータスコード 429"},
+		{"500", 500, "", nil, "ステータスコード 500"},
+		{"503 with an invalid body", 503, "<html>", nil, "ステータスコード 503"},
+		{
+			"authentication error code",
+			400, `{"errors":[{"message":"Authentication failure.","code":11,"moreInfo":""}]}`,
+			[]error{ErrUnauthorized, ErrInvalidRequest}, "Authentication failure. (code: 11)",
+		},
+		{
+			"no resource code",
+			400, `{"errors":[{"message":"No issue.","code":6,"moreInfo":"PROJ-1"}]}`,
+			[]error{ErrNotFound, ErrInvalidRequest}, "No issue. (code: 6) PROJ-1",
+		},
+		{
+			"unauthorized operation code",
+			400, `{"errors":[{"message":"Not allowed.","code":5}]}`,
+			[]error{ErrForbidden, ErrInvalidRequest}, "Not allowed. (code: 5)",
+		},
+		{
+			"too many requests code",
+			400, `{"errors":[{"message":"Too many requests.","code":13}]}`,
+			[]error{ErrRateLimited, ErrInvalidRequest}, "Too many requests. (code: 13)",
+		},
+		{
+			"internal error code",
+			500, `{"errors":[{"message":"a","code":1},{"message":"b","code":9}]}`,
+			nil, "a (code: 1); b (code: 9)",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Callers wrap API errors, so match through a wrapper.
+			err := fmt.Errorf("課題の取得に失敗しました: %w", parseError(tt.status, []byte(tt.body)))
+
+			var apiErr *Error
+			if !errors.As(err, &apiErr) || apiErr.StatusCode != tt.status {
+				t.Fatalf("parseError() = %v, want *Error with status %d", err, tt.status)
+			}
+			if !strings.Contains(err.Error(), tt.wantMsg) {
+				t.Errorf("Error() = %q, want it to contain %q", err.Error(), tt.wantMsg)
+			}
+			for _, s := range sentinels {
+				if got := errors.Is(err, s); got != slices.Contains(tt.want, s) {
+					t.Errorf("errors.Is(err, %v) = %v", s, got)
+				}
+			}
+		})
+	}
+}
@@ -1,51 +1,137 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"iter"
+	"math"
 	"net/url"
 	"strconv"
+	"sync"
+)
+
+// Values of GetIssuesOptions.ParentChild.
+const (
+	ParentChildAll          = 0
+	ParentChildExcludeChild = 1
+	ParentChildChildOnly    = 2
+	ParentChildStandalone   = 3
+	ParentChildParentOnly   = 4
 )
 
 // GetIssuesOptions holds parameters for GetIssues.
 type GetIssuesOptions struct {
-	ProjectIDs   []int
-	AssigneeIDs  []int
-	StatusIDs    []int
-	MilestoneIDs []int
-	Keyword      string
-	Count        int
-	Offset       int
-	Sort         string
-	Order        string
+	ProjectIDs     []int
+	IssueTypeIDs   []int
+	CategoryIDs    []int
+	PriorityIDs    []int
+	AssigneeIDs    []int
+	CreatedUserIDs []int
+	StatusIDs      []int
+	ResolutionIDs  []int
+	MilestoneIDs   []int
+	ParentIssueIDs []int
+	// ParentChild narrows by position in the parent/child hierarchy.
+	ParentChild int
+	// Attachment limits the results to issues with attachments.
+	Attachment bool
+	// Date ranges are inclusive and formatted as yyyy-MM-dd. Empty bounds
+	// are open.
+	CreatedSince   string
+	CreatedUntil   string
+	UpdatedSince   string
+	UpdatedUntil   string
+	StartDateSince string
+	StartDateUntil string
+	DueDateSince   string
+	DueDateUntil   string
+	Keyword        string
+	CustomFields   []CustomFieldFilter
+	Count          int
+	Offset         int
+	Sort           string
+	Order          string
 }
 
-// GetIssues returns issues matching the given options.
-func (c *Client) GetIssues(opts *GetIssuesOptions) ([]Issue, error) {
+// maxIssuesPerPage is the largest count accepted by the /issues endpoint.
+const maxIssuesPerPage = 100
+
+// values returns the filter parameters shared by /issues and /issues/count.
+func (opts *GetIssuesOptions) values() url.Values {
 	params := url.Values{}
 
 	for _, id := range opts.ProjectIDs {
 		params.Add("projectId[]", strconv.Itoa(id))
 	}
+	for _, id := range opts.IssueTypeIDs {
+		params.Add("issueTypeId[]", strconv.Itoa(id))
+	}
+	for _, id := range opts.CategoryIDs {
+		params.Add("categoryId[]", strconv.Itoa(id))
+	}
+	for _, id := range opts.PriorityIDs {
+		params.Add("priorityId[]", strconv.Itoa(id))
+	}
 	for _, id := range opts.AssigneeIDs {
 		params.Add("assigneeId[]", strconv.Itoa(id))
 	}
+	for _, id := range opts.CreatedUserIDs {
+		params.Add("createdUserId[]", strconv.Itoa(id))
+	}
 	for _, id := range opts.StatusIDs {
 		params.Add("statusId[]", strconv.Itoa(id))
 	}
+	for _, id := range opts.ResolutionIDs {
+		params.Add("resolutionId[]", strconv.Itoa(id))
+	}
 	for _, id := range opts.MilestoneIDs {
 		params.Add("milestoneId[]", strconv.Itoa(id))
 	}
+	for _, id := range opts.ParentIssueIDs {
+		params.Add("parentIssueId[]", strconv.Itoa(id))
+	}
+	if opts.ParentChild != ParentChildAll {
+		params.Set("parentChild", strconv.Itoa(opts.ParentChild))
+	}
+	if opts.Attachment {
+		params.Set("attachment", "true")
+	}
+	for name, value := range map[string]string{
+		"createdSince":   opts.CreatedSince,
+		"createdUntil":   opts.CreatedUntil,
+		"updatedSince":   opts.UpdatedSince,
+		"updatedUntil":   opts.UpdatedUntil,
+		"startDateSince": opts.StartDateSince,
+		"startDateUntil": opts.StartDateUntil,
+		"dueDateSince":   opts.DueDateSince,
+		"dueDateUntil":   opts.DueDateUntil,
+	} {
+		if value != "" {
+			params.Set(name, value)
+		}
+	}
 	if opts.Keyword != "" {
 		params.Set("keyword", opts.Keyword)
 	}
+	for _, f := range opts.CustomFields {
+		f.encode(params)
+	}
+	return params
+}
+
+// GetIssues returns a single page of issues matching the given options.
+// Count is clamped to 100; use IterateIssues to fetch more.
+func (c *Client) GetIssues(ctx context.Context, opts *GetIssuesOptions) ([]Issue, error) {
+	params := opts.values()
 
 	count := opts.Count
 	if count <= 0 {
 		count = 20
 	}
-	if count > 100 {
-		count = 100
+	if count > maxIssuesPerPage {
+		count = maxIssuesPerPage
 	}
 	params.Set("count", strconv.Itoa(count))
 
@@ -59,7 +145,7 @@ func (c *Client) GetIssues(opts *GetIssuesOptions) ([]Issue, error) {
 		params.Set("order", opts.Order)
 	}
 
-	data, err := c.get("/issues", params)
+	data, err := c.get(ctx, "/issues", params)
 	if err != nil {
 		return nil, fmt.Errorf("課題一覧の取得に失敗しました: %w", err)
 	}
@@ -71,9 +157,89 @@ func (c *Client) GetIssues(opts *GetIssuesOptions) ([]Issue, error) {
 	return issues, nil
 }
 
+// GetIssuesCount returns the number of issues matching the given options.
+func (c *Client) GetIssuesCount(ctx context.Context, opts *GetIssuesOptions) (int, error) {
+	data, err := c.get(ctx, "/issues/count", opts.values())
+	if err != nil {
+		return 0, fmt.Errorf("課題数の取得に失敗しました: %w", err)
+	}
+	var res struct {
+		Count int `json:"count"`
+	}
+	if err := json.Unmarshal(data, &res); err != nil {
+		return 0, fmt.Errorf("課題数の解析に失敗しました: %w", err)
+	}
+	return res.Count, nil
+}
+
+// IterateIssues yields issues matching opts, walking offset page by page.
+// At most limit issues are yielded; limit <= 0 yields every match.
+// opts.Count and opts.Offset are ignored except as the starting offset.
+func (c *Client) IterateIssues(ctx context.Context, opts *GetIssuesOptions, limit int) iter.Seq2[Issue, error] {
+	return func(yield func(Issue, error) bool) {
+		want := limit
+		if want <= 0 || want > maxIssuesPerPage {
+			total, err := c.GetIssuesCount(ctx, opts)
+			if err != nil {
+				yield(Issue{}, err)
+				return
+			}
+			total -= opts.Offset
+			if want <= 0 || want > total {
+				want = total
+			}
+		}
+
+		page := *opts
+		fetched := 0
+		for fetched < want {
+			page.Count = min(maxIssuesPerPage, want-fetched)
+			page.Offset = opts.Offset + fetched
+
+			issues, err := c.GetIssues(ctx, &page)
+			if err != nil {
+				yield(Issue{}, err)
+				return
+			}
+			for _, issue := range issues {
+				if !yield(issue, nil) {
+					return
+				}
+			}
+			fetched += len(issues)
+			if len(issues) < page.Count {
+				return
+			}
+		}
+	}
+}
+
+// ListIssues collects up to limit issues from IterateIssues.
+// limit <= 0 returns every matching issue.
+func (c *Client) ListIssues(ctx context.Context, opts *GetIssuesOptions, limit int) ([]Issue, error) {
+	issues := []Issue{}
+	for issue, err := range c.IterateIssues(ctx, opts, limit) {
+		if err != nil {
+			return nil, err
+		}
+		issues = append(issues, issue)
+	}
+	return issues, nil
+}
+
+// GetChildIssues returns every child issue of parent.
+func (c *Client) GetChildIssues(ctx context.Context, parent *Issue) ([]Issue, error) {
+	return c.ListIssues(ctx, &GetIssuesOptions{
+		ProjectIDs:     []int{parent.ProjectID},
+		ParentIssueIDs: []int{parent.ID},
+		Sort:           "created",
+		Order:          "asc",
+	}, 0)
+}
+
 // GetIssue returns a single issue by key or ID.
-func (c *Client) GetIssue(issueIDOrKey string) (*Issue, error) {
-	data, err := c.get("/issues/"+issueIDOrKey, nil)
+func (c *Client) GetIssue(ctx context.Context, issueIDOrKey string) (*Issue, error) {
+	data, err := c.get(ctx, "/issues/"+issueIDOrKey, nil)
 	if err != nil {
 		return nil, fmt.Errorf("課題の取得に失敗しました: %w", err)
 	}
@@ -84,6 +250,55 @@ func (c *Client) GetIssue(issueIDOrKey string) (*Issue, error) {
 	return &issue, nil
 }
 
+// maxConcurrentFetches limits parallel requests in GetIssuesByKey.
+const maxConcurrentFetches = 8
+
+// GetIssuesByKey fetches several issues concurrently. Issues that do not
+// exist are left out of the result and returned as missing; the
+// remaining issues keep the order of keys.
+func (c *Client) GetIssuesByKey(ctx context.Context, keys []string) (issues []Issue, missing []string, err error) {
+	results := make([]*Issue, len(keys))
+	errs := make([]error, len(keys))
+	sem := make(chan struct{}, maxConcurrentFetches)
+
+	var wg sync.WaitGroup
+	for i, key := range keys {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			results[i], errs[i] = c.GetIssue(ctx, key)
+		}()
+	}
+	wg.Wait()
+
+	for i, key := range keys {
+		switch {
+		case errors.Is(errs[i], ErrNotFound):
+			missing = append(missing, key)
+		case errs[i] != nil:
+			return nil, nil, errs[i]
+		default:
+			issues = append(issues, *results[i])
+		}
+	}
+	return issues, missing, nil
+}
+
+// DeleteIssue deletes an issue and returns it as it was before deletion.
+func (c *Client) DeleteIssue(ctx context.Context, issueIDOrKey string) (*Issue, error) {
+	data, err := c.delete(ctx, "/issues/"+issueIDOrKey)
+	if err != nil {
+		return nil, fmt.Errorf("課題の削除に失敗しました: %w", err)
+	}
+	var issue Issue
+	if err := json.Unmarshal(data, &issue); err != nil {
+		return nil, fmt.Errorf("課題の解析に失敗しました: %w", err)
+	}
+	return &issue, nil
+}
+
 // CreateIssueOptions holds parameters for CreateIssue.
 type CreateIssueOptions struct {
 	ProjectID   int
@@ -96,10 +311,17 @@ type CreateIssueOptions struct {
 	StartDate   string
 	MilestoneIDs []int
 	CategoryIDs  []int
+	// ParentIssueID makes the new issue a child of another issue.
+	ParentIssueID  int
+	EstimatedHours float64
+
+	// AttachmentIDs are files uploaded with UploadAttachment.
+	AttachmentIDs []int
+	CustomFields  CustomFieldParams
 }
 
 // CreateIssue creates a new issue.
-func (c *Client) CreateIssue(opts *CreateIssueOptions) (*Issue, error) {
+func (c *Client) CreateIssue(ctx context.Context, opts *CreateIssueOptions) (*Issue, error) {
 	params := url.Values{}
 	params.Set("projectId", strconv.Itoa(opts.ProjectID))
 	params.Set("summary", opts.Summary)
@@ -118,14 +340,24 @@ func (c *Client) CreateIssue(opts *CreateIssueOptions) (*Issue, error) {
 	if opts.StartDate != "" {
 		params.Set("startDate", opts.StartDate)
 	}
+	if opts.ParentIssueID > 0 {
+		params.Set("parentIssueId", strconv.Itoa(opts.ParentIssueID))
+	}
+	if opts.EstimatedHours > 0 {
+		params.Set("estimatedHours", formatHours(opts.EstimatedHours))
+	}
 	for _, id := range opts.MilestoneIDs {
 		params.Add("milestoneId[]", strconv.Itoa(id))
 	}
 	for _, id := range opts.CategoryIDs {
 		params.Add("categoryId[]", strconv.Itoa(id))
 	}
+	for _, id := range opts.AttachmentIDs {
+		params.Add("attachmentId[]", strconv.Itoa(id))
+	}
+	opts.CustomFields.encode(params)
 
-	data, err := c.post("/issues", params)
+	data, err := c.post(ctx, "/issues", params)
 	if err != nil {
 		return nil, fmt.Errorf("課題の作成に失敗しました: %w", err)
 	}
@@ -142,6 +374,7 @@ type UpdateIssueOptions struct {
 	Summary      *string
 	Description  *string
 	StatusID     *int
+	ResolutionID *int
 	AssigneeID   *int
 	PriorityID   *int
 	DueDate      *string
@@ -149,10 +382,20 @@ type UpdateIssueOptions struct {
 	MilestoneIDs []int
 	CategoryIDs  []int
 	Comment      *string
+	// ParentIssueID sets the parent issue; 0 makes the issue top-level.
+	ParentIssueID  *int
+	EstimatedHours *float64
+	ActualHours    *float64
+
+	// NotifiedUserIDs are notified of the update comment.
+	NotifiedUserIDs []int
+	// AttachmentIDs are files uploaded with UploadAttachment.
+	AttachmentIDs []int
+	CustomFields  CustomFieldParams
 }
 
 // UpdateIssue updates an existing issue.
-func (c *Client) UpdateIssue(issueIDOrKey string, opts *UpdateIssueOptions) (*Issue, error) {
+func (c *Client) UpdateIssue(ctx context.Context, issueIDOrKey string, opts *UpdateIssueOptions) (*Issue, error) {
 	params := url.Values{}
 
 	if opts.Summary != nil {
@@ -164,6 +407,9 @@ func (c *Client) UpdateIssue(issueIDOrKey string, opts *UpdateIssueOptions) (*Is
 	if opts.StatusID != nil {
 		params.Set("statusId", strconv.Itoa(*opts.StatusID))
 	}
+	if opts.ResolutionID != nil {
+		params.Set("resolutionId", strconv.Itoa(*opts.ResolutionID))
+	}
 	if opts.AssigneeID != nil {
 		params.Set("assigneeId", strconv.Itoa(*opts.AssigneeID))
 	}
@@ -176,17 +422,37 @@ func (c *Client) UpdateIssue(issueIDOrKey string, opts *UpdateIssueOptions) (*Is
 	if opts.StartDate != nil {
 		params.Set("startDate", *opts.StartDate)
 	}
+	if opts.EstimatedHours != nil {
+		params.Set("estimatedHours", formatHours(*opts.EstimatedHours))
+	}
+	if opts.ActualHours != nil {
+		params.Set("actualHours", formatHours(*opts.ActualHours))
+	}
+	if opts.ParentIssueID != nil {
+		if *opts.ParentIssueID > 0 {
+			params.Set("parentIssueId", strconv.Itoa(*opts.ParentIssueID))
+		} else {
+			params.Set("parentIssueId", "")
+		}
+	}
 	for _, id := range opts.MilestoneIDs {
 		params.Add("milestoneId[]", strconv.Itoa(id))
 	}
 	for _, id := range opts.CategoryIDs {
 		params.Add("categoryId[]", strconv.Itoa(id))
 	}
+	for _, id := range opts.AttachmentIDs {
+		params.Add("attachmentId[]", strconv.Itoa(id))
+	}
+	opts.CustomFields.encode(params)
 	if opts.Comment != nil {
 		params.Set("comment", *opts.Comment)
 	}
+	for _, id := range opts.NotifiedUserIDs {
+		params.Add("notifiedUserId[]", strconv.Itoa(id))
+	}
 
-	data, err := c.patch("/issues/"+issueIDOrKey, params)
+	data, err := c.patch(ctx, "/issues/"+issueIDOrKey, params)
 	if err != nil {
 		return nil, fmt.Errorf("課題の更新に失敗しました: %w", err)
 	}
@@ -197,12 +463,30 @@ func (c *Client) UpdateIssue(issueIDOrKey string, opts *UpdateIssueOptions) (*Is
 	return &updated, nil
 }
 
+// formatHours formats hours for the API, which accepts two decimal places.
+func formatHours(h float64) string {
+	return strconv.FormatFloat(math.Round(h*100)/100, 'f', -1, 64)
+}
+
+// AddCommentOptions holds parameters for AddComment.
+type AddCommentOptions struct {
+	Content         string
+	NotifiedUserIDs []int
+	AttachmentIDs   []int
+}
+
 // AddComment adds a comment to an issue.
-func (c *Client) AddComment(issueIDOrKey string, content string) (*Comment, error) {
+func (c *Client) AddComment(ctx context.Context, issueIDOrKey string, opts *AddCommentOptions) (*Comment, error) {
 	params := url.Values{}
-	params.Set("content", content)
+	params.Set("content", opts.Content)
+	for _, id := range opts.NotifiedUserIDs {
+		params.Add("notifiedUserId[]", strconv.Itoa(id))
+	}
+	for _, id := range opts.AttachmentIDs {
+		params.Add("attachmentId[]", strconv.Itoa(id))
+	}
 
-	data, err := c.post("/issues/"+issueIDOrKey+"/comments", params)
+	data, err := c.post(ctx, "/issues/"+issueIDOrKey+"/comments", params)
 	if err != nil {
 		return nil, fmt.Errorf("コメントの追加に失敗しました: %w", err)
 	}
@@ -214,7 +498,7 @@ func (c *Client) AddComment(issueIDOrKey string, content string) (*Comment, erro
 }
 
 // GetComments returns comments for an issue.
-func (c *Client) GetComments(issueIDOrKey string, count int, order string) ([]Comment, error) {
+func (c *Client) GetComments(ctx context.Context, issueIDOrKey string, count int, order string) ([]Comment, error) {
 	params := url.Values{}
 	if count > 0 {
 		params.Set("count", strconv.Itoa(count))
@@ -223,7 +507,7 @@ func (c *Client) GetComments(issueIDOrKey string, count int, order string) ([]Co
 		params.Set("order", order)
 	}
 
-	data, err := c.get("/issues/"+issueIDOrKey+"/comments", params)
+	data, err := c.get(ctx, "/issues/"+issueIDOrKey+"/comments", params)
 	if err != nil {
 		return nil, fmt.Errorf("コメント一覧の取得に失敗しました: %w", err)
 	}
@@ -233,3 +517,46 @@ func (c *Client) GetComments(issueIDOrKey string, count int, order string) ([]Co
 	}
 	return comments, nil
 }
+
+// GetComment returns a single comment of an issue.
+func (c *Client) GetComment(ctx context.Context, issueIDOrKey string, commentID int) (*Comment, error) {
+	data, err := c.get(ctx, "/issues/"+issueIDOrKey+"/comments/"+strconv.Itoa(commentID), nil)
+	if err != nil {
+		return nil, fmt.Errorf("コメントの取得に失敗しました: %w", err)
+	}
+	var comment Comment
+	if err := json.Unmarshal(data, &comment); err != nil {
+		return nil, fmt.Errorf("コメントの解析に失敗しました: %w", err)
+	}
+	return &comment, nil
+}
+
+// UpdateComment replaces the content of a comment.
+func (c *Client) UpdateComment(ctx context.Context, issueIDOrKey string, commentID int, content string) (*Comment, error) {
+	params := url.Values{}
+	params.Set("content", content)
+
+	data, err := c.patch(ctx, "/issues/"+issueIDOrKey+"/comments/"+strconv.Itoa(commentID), params)
+	if err != nil {
+		return nil, fmt.Errorf("コメントの更新に失敗しました: %w", err)
+	}
+	var comment Comment
+	if err := json.Unmarshal(data, &comment); err != nil {
+		return nil, fmt.Errorf("コメントの解析に失敗しました: %w", err)
+	}
+	return &comment, nil
+}
+
+// DeleteComment deletes a comment of an issue and returns it as it was
+// before deletion.
+func (c *Client) DeleteComment(ctx context.Context, issueIDOrKey string, commentID int) (*Comment, error) {
+	data, err := c.delete(ctx, "/issues/"+issueIDOrKey+"/comments/"+strconv.Itoa(commentID))
+	if err != nil {
+		return nil, fmt.Errorf("コメントの削除に失敗しました: %w", err)
+	}
+	var comment Comment
+	if err := json.Unmarshal(data, &comment); err != nil {
+		return nil, fmt.Errorf("コメントの解析に失敗しました: %w", err)
+	}
+	return &comment, nil
+}
@@ -0,0 +1,153 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+// issueServer serves /issues and /issues/count from a list of n issues.
+type issueServer struct {
+	n         int // issues that exist
+	count     int // total reported by /issues/count
+	failAt    int // offset answered with 400, or -1
+	mu        sync.Mutex
+	requests  []string
+	countHits int
+}
+
+func (s *issueServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	switch r.URL.Path {
+	case "/api/v2/issues/count":
+		s.countHits++
+		fmt.Fprintf(w, `{"count":%d}`, s.count)
+	case "/api/v2/issues":
+		offset, _ := strconv.Atoi(q.Get("offset"))
+		count, _ := strconv.Atoi(q.Get("count"))
+		s.requests = append(s.requests, fmt.Sprintf("%d+%d", offset, count))
+		if offset == s.failAt {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"errors":[{"message":"bad offset","code":7}]}`))
+			return
+		}
+		issues := []Issue{}
+		for i := offset; i < min(offset+count, s.n); i++ {
+			issues = append(issues, Issue{ID: i + 1, IssueKey: fmt.Sprintf("PROJ-%d", i+1)})
+		}
+		json.NewEncoder(w).Encode(issues)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func newIssueServer(t *testing.T, s *issueServer) *Client {
+	t.Helper()
+	srv := httptest.NewServer(s)
+	t.Cleanup(srv.Close)
+	return NewClient(srv.URL, "key", WithMaxRetries(0))
+}
+
+func TestListIssues(t *testing.T) {
+	tests := []struct {
+		name         string
+		n, count     int
+		offset       int
+		limit        int
+		want         int
+		wantRequests string
+		wantCount    int
+	}{
+		{"every match", 250, 250, 0, 0, 250, "[0+100 100+100 200+50]", 1},
+		{"full last page", 200, 200, 0, 0, 200, "[0+100 100+100]", 1},
+		{"short last page", 250, 300, 0, 0, 250, "[0+100 100+100 200+100]", 1},
+		{"limit within a page", 250, 250, 0, 30, 30, "[0+30]", 0},
+		{"limit stops mid page", 250, 250, 0, 150, 150, "[0+100 100+50]", 1},
+		{"limit above total", 120, 120, 0, 500, 120, "[0+100 100+20]", 1},
+		{"starting offset", 250, 250, 200, 0, 50, "[200+50]", 1},
+		{"no match", 0, 0, 0, 0, 0, "[]", 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &issueServer{n: tt.n, count: tt.count, failAt: -1}
+			c := newIssueServer(t, s)
+
+			issues, err := c.ListIssues(context.Background(), &GetIssuesOptions{Offset: tt.offset}, tt.limit)
+			if err != nil {
+				t.Fatalf("ListIssues() error = %v", err)
+			}
+			if len(issues) != tt.want {
+				t.Fatalf("ListIssues() = %d issues, want %d", len(issues), tt.want)
+			}
+			if tt.want > 0 && issues[0].ID != tt.offset+1 {
+				t.Errorf("first issue = %d, want %d", issues[0].ID, tt.offset+1)
+			}
+			if got := fmt.Sprint(s.requests); got != tt.wantRequests {
+				t.Errorf("requests = %s, want %s", got, tt.wantRequests)
+			}
+			if s.countHits != tt.wantCount {
+				t.Errorf("/issues/count requests = %d, want %d", s.countHits, tt.wantCount)
+			}
+		})
+	}
+}
+
+func TestIterateIssuesError(t *testing.T) {
+	s := &issueServer{n: 250, count: 250, failAt: 100}
+	c := newIssueServer(t, s)
+
+	var got int
+	var gotErr error
+	for issue, err := range c.IterateIssues(context.Background(), &GetIssuesOptions{}, 0) {
+		if err != nil {
+			gotErr = err
+			break
+		}
+		if issue.ID != got+1 {
+			t.Fatalf("issue %d out of order, want %d", issue.ID, got+1)
+		}
+		got++
+	}
+	if got != 100 || !errors.Is(gotErr, ErrInvalidRequest) {
+		t.Errorf("IterateIssues() yielded %d issues and %v, want 100 and ErrInvalidRequest", got, gotErr)
+	}
+
+	if _, err := c.ListIssues(context.Background(), &GetIssuesOptions{}, 0); !errors.Is(err, ErrInvalidRequest) {
+		t.Errorf("ListIssues() error = %v, want ErrInvalidRequest", err)
+	}
+}
+
+func TestIterateIssuesStopsEarly(t *testing.T) {
+	s := &issueServer{n: 250, count: 250, failAt: -1}
+	c := newIssueServer(t, s)
+
+	var got int
+	for _, err := range c.IterateIssues(context.Background(), &GetIssuesOptions{}, 0) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got++; got == 5 {
+			break
+		}
+	}
+	if len(s.requests) != 1 {
+		t.Errorf("requests = %v after breaking in the first page, want one", s.requests)
+	}
+}
+
+func TestGetIssuesCount(t *testing.T) {
+	s := &issueServer{count: 1234, failAt: -1}
+	c := newIssueServer(t, s)
+	got, err := c.GetIssuesCount(context.Background(), &GetIssuesOptions{})
+	if err != nil || got != 1234 {
+		t.Errorf("GetIssuesCount() = %d, %v, want 1234", got, err)
+	}
+}
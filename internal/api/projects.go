@@ -1,13 +1,14 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 )
 
 // GetProjects returns all projects.
-func (c *Client) GetProjects() ([]Project, error) {
-	data, err := c.get("/projects", nil)
+func (c *Client) GetProjects(ctx context.Context) ([]Project, error) {
+	data, err := c.get(ctx, "/projects", nil)
 	if err != nil {
 		return nil, fmt.Errorf("プロジェクト一覧の取得に失敗しました: %w", err)
 	}
@@ -19,8 +20,8 @@ func (c *Client) GetProjects() ([]Project, error) {
 }
 
 // GetProject returns a single project.
-func (c *Client) GetProject(projectIDOrKey string) (*Project, error) {
-	data, err := c.get("/projects/"+projectIDOrKey, nil)
+func (c *Client) GetProject(ctx context.Context, projectIDOrKey string) (*Project, error) {
+	data, err := c.get(ctx, "/projects/"+projectIDOrKey, nil)
 	if err != nil {
 		return nil, fmt.Errorf("プロジェクトの取得に失敗しました: %w", err)
 	}
@@ -32,8 +33,8 @@ func (c *Client) GetProject(projectIDOrKey string) (*Project, error) {
 }
 
 // GetProjectUsers returns users belonging to a project.
-func (c *Client) GetProjectUsers(projectIDOrKey string) ([]User, error) {
-	data, err := c.get("/projects/"+projectIDOrKey+"/users", nil)
+func (c *Client) GetProjectUsers(ctx context.Context, projectIDOrKey string) ([]User, error) {
+	data, err := c.get(ctx, "/projects/"+projectIDOrKey+"/users", nil)
 	if err != nil {
 		return nil, fmt.Errorf("プロジェクトユーザーの取得に失敗しました: %w", err)
 	}
@@ -45,8 +46,8 @@ func (c *Client) GetProjectUsers(projectIDOrKey string) ([]User, error) {
 }
 
 // GetStatuses returns statuses for a project.
-func (c *Client) GetStatuses(projectIDOrKey string) ([]Status, error) {
-	data, err := c.get("/projects/"+projectIDOrKey+"/statuses", nil)
+func (c *Client) GetStatuses(ctx context.Context, projectIDOrKey string) ([]Status, error) {
+	data, err := c.get(ctx, "/projects/"+projectIDOrKey+"/statuses", nil)
 	if err != nil {
 		return nil, fmt.Errorf("ステータス一覧の取得に失敗しました: %w", err)
 	}
@@ -58,8 +59,8 @@ func (c *Client) GetStatuses(projectIDOrKey string) ([]Status, error) {
 }
 
 // GetIssueTypes returns issue types for a project.
-func (c *Client) GetIssueTypes(projectIDOrKey string) ([]IssueType, error) {
-	data, err := c.get("/projects/"+projectIDOrKey+"/issueTypes", nil)
+func (c *Client) GetIssueTypes(ctx context.Context, projectIDOrKey string) ([]IssueType, error) {
+	data, err := c.get(ctx, "/projects/"+projectIDOrKey+"/issueTypes", nil)
 	if err != nil {
 		return nil, fmt.Errorf("課題種別一覧の取得に失敗しました: %w", err)
 	}
@@ -71,8 +72,8 @@ func (c *Client) GetIssueTypes(projectIDOrKey string) ([]IssueType, error) {
 }
 
 // GetPriorities returns all priorities.
-func (c *Client) GetPriorities() ([]Priority, error) {
-	data, err := c.get("/priorities", nil)
+func (c *Client) GetPriorities(ctx context.Context) ([]Priority, error) {
+	data, err := c.get(ctx, "/priorities", nil)
 	if err != nil {
 		return nil, fmt.Errorf("優先度一覧の取得に失敗しました: %w", err)
 	}
@@ -83,9 +84,22 @@ func (c *Client) GetPriorities() ([]Priority, error) {
 	return priorities, nil
 }
 
+// GetResolutions returns all issue resolutions.
+func (c *Client) GetResolutions(ctx context.Context) ([]Resolution, error) {
+	data, err := c.get(ctx, "/resolutions", nil)
+	if err != nil {
+		return nil, fmt.Errorf("完了理由一覧の取得に失敗しました: %w", err)
+	}
+	var resolutions []Resolution
+	if err := json.Unmarshal(data, &resolutions); err != nil {
+		return nil, fmt.Errorf("完了理由一覧の解析に失敗しました: %w", err)
+	}
+	return resolutions, nil
+}
+
 // GetMilestones returns milestones for a project.
-func (c *Client) GetMilestones(projectIDOrKey string) ([]Milestone, error) {
-	data, err := c.get("/projects/"+projectIDOrKey+"/versions", nil)
+func (c *Client) GetMilestones(ctx context.Context, projectIDOrKey string) ([]Milestone, error) {
+	data, err := c.get(ctx, "/projects/"+projectIDOrKey+"/versions", nil)
 	if err != nil {
 		return nil, fmt.Errorf("マイルストーン一覧の取得に失敗しました: %w", err)
 	}
@@ -97,8 +111,8 @@ func (c *Client) GetMilestones(projectIDOrKey string) ([]Milestone, error) {
 }
 
 // GetCategories returns categories for a project.
-func (c *Client) GetCategories(projectIDOrKey string) ([]Category, error) {
-	data, err := c.get("/projects/"+projectIDOrKey+"/categories", nil)
+func (c *Client) GetCategories(ctx context.Context, projectIDOrKey string) ([]Category, error) {
+	data, err := c.get(ctx, "/projects/"+projectIDOrKey+"/categories", nil)
 	if err != nil {
 		return nil, fmt.Errorf("カテゴリ一覧の取得に失敗しました: %w", err)
 	}
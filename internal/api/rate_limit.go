@@ -0,0 +1,22 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// GetRateLimit returns the current quota of each rate-limit bucket.
+func (c *Client) GetRateLimit(ctx context.Context) (*RateLimitStatus, error) {
+	data, err := c.get(ctx, "/rateLimit", nil)
+	if err != nil {
+		return nil, fmt.Errorf("レート制限の取得に失敗しました: %w", err)
+	}
+	var res struct {
+		RateLimit RateLimitStatus `json:"rateLimit"`
+	}
+	if err := json.Unmarshal(data, &res); err != nil {
+		return nil, fmt.Errorf("レート制限の解析に失敗しました: %w", err)
+	}
+	return &res.RateLimit, nil
+}
@@ -1,5 +1,7 @@
 package api
 
+import "time"
+
 // User represents a Backlog user.
 type User struct {
 	ID          int    `json:"id"`
@@ -21,19 +23,25 @@ type Issue struct {
 	ID          int        `json:"id"`
 	ProjectID   int        `json:"projectId"`
 	IssueKey    string     `json:"issueKey"`
+	ParentIssueID *int     `json:"parentIssueId"`
 	Summary     string     `json:"summary"`
 	Description string     `json:"description"`
 	Status      *Status    `json:"status"`
+	Resolution  *Resolution `json:"resolution"`
 	Assignee    *User      `json:"assignee"`
 	Priority    *Priority  `json:"priority"`
 	IssueType   *IssueType `json:"issueType"`
 	DueDate     string     `json:"dueDate"`
 	StartDate   string     `json:"startDate"`
+	EstimatedHours *float64 `json:"estimatedHours"`
+	ActualHours    *float64 `json:"actualHours"`
 	CreatedUser *User      `json:"createdUser"`
 	Created     string     `json:"created"`
 	Updated     string     `json:"updated"`
 	Milestone   []Milestone `json:"milestone"`
 	Category    []Category  `json:"category"`
+	Attachments []Attachment `json:"attachments"`
+	CustomFields []CustomFieldValue `json:"customFields"`
 }
 
 // IssueType represents a Backlog issue type.
@@ -52,12 +60,34 @@ type Status struct {
 	Color     string `json:"color"`
 }
 
+// IDs of the statuses every Backlog project starts with.
+const (
+	StatusIDOpen     = 1 // 未対応
+	StatusIDResolved = 3 // 処理済み
+	StatusIDClosed   = 4 // 完了
+)
+
+// IsClosed reports whether s is the status that closes issues: the one
+// named closeStatus if it is set, or else the built-in 完了.
+func (s Status) IsClosed(closeStatus string) bool {
+	if closeStatus != "" {
+		return s.Name == closeStatus
+	}
+	return s.ID == StatusIDClosed
+}
+
 // Priority represents a Backlog priority.
 type Priority struct {
 	ID   int    `json:"id"`
 	Name string `json:"name"`
 }
 
+// Resolution represents a Backlog issue resolution, e.g. 対応済み.
+type Resolution struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
 // Comment represents a Backlog comment.
 type Comment struct {
 	ID          int          `json:"id"`
@@ -67,11 +97,40 @@ type Comment struct {
 	ChangeLog   []ChangeLog  `json:"changeLog"`
 }
 
+// Attachments returns the files attached with the comment, which Backlog
+// records as "attachment" change logs.
+func (c Comment) Attachments() []AttachmentInfo {
+	var infos []AttachmentInfo
+	for _, cl := range c.ChangeLog {
+		if cl.Field == "attachment" && cl.AttachmentInfo != nil && cl.NewValue != "" {
+			infos = append(infos, *cl.AttachmentInfo)
+		}
+	}
+	return infos
+}
+
 // ChangeLog represents a field change in a comment.
 type ChangeLog struct {
-	Field         string `json:"field"`
-	NewValue      string `json:"newValue"`
-	OriginalValue string `json:"originalValue"`
+	Field          string          `json:"field"`
+	NewValue       string          `json:"newValue"`
+	OriginalValue  string          `json:"originalValue"`
+	AttachmentInfo *AttachmentInfo `json:"attachmentInfo,omitempty"`
+}
+
+// Attachment represents a file attached to an issue, or one uploaded to
+// the space and not linked yet.
+type Attachment struct {
+	ID          int    `json:"id"`
+	Name        string `json:"name"`
+	Size        int64  `json:"size"`
+	CreatedUser *User  `json:"createdUser,omitempty"`
+	Created     string `json:"created,omitempty"`
+}
+
+// AttachmentInfo identifies the attachment a change log refers to.
+type AttachmentInfo struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
 }
 
 // Milestone represents a Backlog milestone/version.
@@ -99,3 +158,23 @@ type BacklogError struct {
 type BacklogErrorResponse struct {
 	Errors []BacklogError `json:"errors"`
 }
+
+// RateLimit represents the quota of one Backlog rate-limit bucket.
+type RateLimit struct {
+	Limit     int   `json:"limit"`
+	Remaining int   `json:"remaining"`
+	Reset     int64 `json:"reset"`
+}
+
+// ResetTime returns when the quota is replenished.
+func (r RateLimit) ResetTime() time.Time {
+	return time.Unix(r.Reset, 0)
+}
+
+// RateLimitStatus holds the quota of each rate-limit bucket.
+type RateLimitStatus struct {
+	Read   RateLimit `json:"read"`
+	Update RateLimit `json:"update"`
+	Search RateLimit `json:"search"`
+	Icon   RateLimit `json:"icon"`
+}
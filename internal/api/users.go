@@ -1,13 +1,16 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"regexp"
+	"strings"
 )
 
 // GetMyself returns the authenticated user.
-func (c *Client) GetMyself() (*User, error) {
-	data, err := c.get("/users/myself", nil)
+func (c *Client) GetMyself(ctx context.Context) (*User, error) {
+	data, err := c.get(ctx, "/users/myself", nil)
 	if err != nil {
 		return nil, fmt.Errorf("ユーザー情報の取得に失敗しました: %w", err)
 	}
@@ -18,3 +21,60 @@ func (c *Client) GetMyself() (*User, error) {
 	}
 	return &user, nil
 }
+
+// mentionPattern matches @mentions in comment text. A mention must start
+// the text or follow whitespace so that mail addresses are not picked up.
+var mentionPattern = regexp.MustCompile(`(?:^|\s)@([^\s@]+)`)
+
+// FindUsers looks up users by name or user ID. Every name must match.
+func FindUsers(users []User, names []string) ([]User, error) {
+	var found []User
+	for _, name := range names {
+		name = strings.TrimPrefix(strings.TrimSpace(name), "@")
+		if name == "" {
+			continue
+		}
+		u := findUser(users, name)
+		if u == nil {
+			return nil, fmt.Errorf("ユーザー「%s」が見つかりません", name)
+		}
+		found = append(found, *u)
+	}
+	return found, nil
+}
+
+// MentionedUsers returns the users mentioned as @userId or @name in text.
+// Mentions that match no user are ignored.
+func MentionedUsers(users []User, text string) []User {
+	var found []User
+	for _, m := range mentionPattern.FindAllStringSubmatch(text, -1) {
+		// Allow trailing punctuation such as "@taro、" or "@taro:"
+		name := strings.TrimRight(m[1], ".,:;!?、。：")
+		if u := findUser(users, name); u != nil {
+			found = append(found, *u)
+		}
+	}
+	return found
+}
+
+// UserIDs returns the numeric IDs of users without duplicates.
+func UserIDs(users []User) []int {
+	var ids []int
+	seen := make(map[int]bool)
+	for _, u := range users {
+		if !seen[u.ID] {
+			seen[u.ID] = true
+			ids = append(ids, u.ID)
+		}
+	}
+	return ids
+}
+
+func findUser(users []User, name string) *User {
+	for i, u := range users {
+		if u.UserID == name || u.Name == name {
+			return &users[i]
+		}
+	}
+	return nil
+}
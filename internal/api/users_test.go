@@ -0,0 +1,67 @@
+package api
+
+import (
+	"reflect"
+	"testing"
+)
+
+var testUsers = []User{
+	{ID: 1, UserID: "taro", Name: "山田太郎"},
+	{ID: 2, UserID: "hanako", Name: "佐藤花子"},
+	{ID: 3, UserID: "", Name: "bot"},
+}
+
+func TestMentionedUsers(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []int
+	}{
+		{"none", "確認しました", nil},
+		{"user ID", "@taro 確認お願いします", []int{1}},
+		{"name", "@佐藤花子 レビューお願いします", []int{2}},
+		{"several", "@taro と @hanako へ", []int{1, 2}},
+		{"after newline", "一行目\n@hanako", []int{2}},
+		{"trailing punctuation", "@taro、 @hanako: @bot.", []int{1, 2, 3}},
+		{"full-width punctuation", "@taro。", []int{1}},
+		{"mail address", "taro@example.com に送りました", nil},
+		{"unknown user", "@jiro 確認お願いします", nil},
+		{"inside a word", "x@taro", nil},
+		{"repeated", "@taro @taro", []int{1, 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []int
+			for _, u := range MentionedUsers(testUsers, tt.text) {
+				got = append(got, u.ID)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("MentionedUsers(%q) = %v, want %v", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFindUsers(t *testing.T) {
+	got, err := FindUsers(testUsers, []string{"@hanako", " 山田太郎 ", ""})
+	if err != nil {
+		t.Fatalf("FindUsers() error = %v", err)
+	}
+	if ids := UserIDs(got); !reflect.DeepEqual(ids, []int{2, 1}) {
+		t.Errorf("FindUsers() = %v, want [2 1]", ids)
+	}
+
+	if _, err := FindUsers(testUsers, []string{"taro", "jiro"}); err == nil {
+		t.Error("FindUsers(jiro) succeeded, want error")
+	}
+}
+
+func TestUserIDs(t *testing.T) {
+	users := []User{{ID: 2}, {ID: 1}, {ID: 2}}
+	if got := UserIDs(users); !reflect.DeepEqual(got, []int{2, 1}) {
+		t.Errorf("UserIDs() = %v, want [2 1]", got)
+	}
+	if got := UserIDs(nil); got != nil {
+		t.Errorf("UserIDs(nil) = %v, want nil", got)
+	}
+}
@@ -0,0 +1,40 @@
+package cmdutil
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/KimMaru10/bl-cli/internal/api"
+)
+
+// UploadFiles uploads the files at paths to the space and returns the
+// attachment IDs to link them with. All files are checked before any is
+// uploaded so that a typo does not leave half of them behind.
+func UploadFiles(ctx context.Context, client *api.Client, paths []string) ([]int, error) {
+	for _, p := range paths {
+		info, err := os.Stat(p)
+		if err != nil {
+			return nil, fmt.Errorf("添付ファイルを開けません: %w", err)
+		}
+		if info.IsDir() {
+			return nil, fmt.Errorf("%s はディレクトリです", p)
+		}
+	}
+
+	var ids []int
+	for _, p := range paths {
+		f, err := os.Open(p)
+		if err != nil {
+			return nil, fmt.Errorf("添付ファイルを開けません: %w", err)
+		}
+		attachment, err := client.UploadAttachment(ctx, filepath.Base(p), f)
+		f.Close()
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, attachment.ID)
+	}
+	return ids, nil
+}
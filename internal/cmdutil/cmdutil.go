@@ -2,25 +2,105 @@ package cmdutil
 
 import (
 	"fmt"
+	"os"
 
 	"github.com/KimMaru10/bl-cli/internal/api"
 	"github.com/KimMaru10/bl-cli/internal/config"
+	"github.com/KimMaru10/bl-cli/internal/tui"
+	"github.com/spf13/cobra"
 )
 
+var (
+	// timeout is the per-request API timeout set by the global --timeout flag.
+	timeout = api.DefaultTimeout
+	// spaceAlias is the space alias set by the global --space flag.
+	spaceAlias string
+)
+
+// AddGlobalFlags registers flags shared by every command on root.
+func AddGlobalFlags(root *cobra.Command) {
+	root.PersistentFlags().DurationVar(&timeout, "timeout", api.DefaultTimeout, "API リクエストのタイムアウト（0 で無制限）")
+	root.PersistentFlags().StringVar(&spaceAlias, "space", "", "このコマンドだけ使用するスペースのエイリアス")
+}
+
+// NewClient creates an API client configured from the global flags.
+// Setting BL_DEBUG logs retries and rate-limit headers to stderr.
+func NewClient(spaceURL, apiKey string, opts ...api.Option) *api.Client {
+	opts = append([]api.Option{api.WithTimeout(timeout)}, opts...)
+	if os.Getenv("BL_DEBUG") != "" {
+		opts = append(opts, api.WithDebug(os.Stderr))
+	}
+	return api.NewClient(spaceURL, apiKey, opts...)
+}
+
+// NewSpaceClient creates an API client for a space whose credentials have
+// been loaded, authenticating with either its API key or its OAuth token.
+// Refreshed OAuth tokens are saved back under alias.
+func NewSpaceClient(alias string, space *config.SpaceConfig) *api.Client {
+	if space.AuthType == config.AuthOAuth && space.OAuth != nil {
+		return NewClient(space.SpaceURL, "", api.WithTokenSource(newTokenSource(alias, space)))
+	}
+	return NewClient(space.SpaceURL, space.APIKey)
+}
+
+// LoadConfig loads the config file and applies the global --space flag.
+func LoadConfig() (*config.Config, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, fmt.Errorf("設定の読み込みに失敗しました: %w", err)
+	}
+	if spaceAlias != "" {
+		if err := cfg.UseSpace(spaceAlias); err != nil {
+			return nil, err
+		}
+	}
+	return cfg, nil
+}
+
 // LoadConfigAndClient loads the config file and creates an API client
 // for the currently active space.
 // Returns an error if not authenticated.
 func LoadConfigAndClient() (*config.Config, *api.Client, error) {
-	cfg, err := config.Load()
+	cfg, err := LoadConfig()
 	if err != nil {
-		return nil, nil, fmt.Errorf("設定の読み込みに失敗しました: %w", err)
+		return nil, nil, err
 	}
 
-	space := cfg.Current()
-	if space == nil || space.APIKey == "" {
-		return nil, nil, fmt.Errorf("未認証です。bl auth login を先に実行してください")
+	space, err := CurrentSpace(cfg)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	client := api.NewClient(space.SpaceURL, space.APIKey)
+	client := NewSpaceClient(cfg.CurrentName(), space)
 	return cfg, client, nil
 }
+
+// CurrentSpace returns the active space with its credentials loaded from the
+// configured secret store.
+// Returns an error if not authenticated.
+func CurrentSpace(cfg *config.Config) (*config.SpaceConfig, error) {
+	if err := cfg.CheckSpace(); err != nil {
+		return nil, err
+	}
+	space := cfg.Current()
+	if space == nil {
+		return nil, ErrNotLoggedIn
+	}
+	if err := space.LoadCredentials(cfg.CurrentName()); err != nil {
+		return nil, err
+	}
+	if !space.Authenticated() {
+		return nil, ErrNotLoggedIn
+	}
+	return space, nil
+}
+
+// PromptPassphrase asks for the passphrase of the encrypted credentials
+// file. It is installed as config.PromptPassphrase by the root command.
+func PromptPassphrase(prompt string) (string, error) {
+	p, ok := tui.Password(prompt)
+	if !ok {
+		return "", fmt.Errorf("パスフレーズの入力がキャンセルされました")
+	}
+	return p, nil
+}
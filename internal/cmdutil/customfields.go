@@ -0,0 +1,162 @@
+package cmdutil
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/KimMaru10/bl-cli/internal/api"
+)
+
+// FieldArg is a custom field given on the command line as name=value.
+type FieldArg struct {
+	Name  string
+	Value string
+}
+
+// ParseFieldArgs parses --field flags of the form name=value.
+func ParseFieldArgs(args []string) ([]FieldArg, error) {
+	var fields []FieldArg
+	for _, arg := range args {
+		name, value, ok := strings.Cut(arg, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("カスタム属性は 名前=値 の形式で指定してください: %s", arg)
+		}
+		fields = append(fields, FieldArg{Name: name, Value: strings.TrimSpace(value)})
+	}
+	return fields, nil
+}
+
+// FindCustomField returns the custom field named name.
+func FindCustomField(defs []api.CustomField, name string) (*api.CustomField, error) {
+	for i, f := range defs {
+		if f.Name == name {
+			return &defs[i], nil
+		}
+	}
+	names := make([]string, len(defs))
+	for i, f := range defs {
+		names[i] = f.Name
+	}
+	return nil, fmt.Errorf("カスタム属性「%s」が見つかりません（利用可能: %s）", name, strings.Join(names, ", "))
+}
+
+// CustomFieldParams converts field arguments into values to set. Values
+// are checked against the field type; list fields take item names,
+// separated by commas for multiple choice fields. An empty value clears
+// the field.
+func CustomFieldParams(defs []api.CustomField, args []FieldArg) (api.CustomFieldParams, error) {
+	params := api.CustomFieldParams{}
+	for _, arg := range args {
+		def, err := FindCustomField(defs, arg.Name)
+		if err != nil {
+			return nil, err
+		}
+		values, err := CustomFieldValues(*def, arg.Value)
+		if err != nil {
+			return nil, err
+		}
+		params[def.ID] = values
+	}
+	return params, nil
+}
+
+// CustomFieldValues converts value into the parameter values of field def.
+func CustomFieldValues(def api.CustomField, value string) ([]string, error) {
+	if value == "" {
+		return nil, nil
+	}
+	switch def.TypeID {
+	case api.CustomFieldNumber:
+		if n, err := strconv.ParseFloat(value, 64); err != nil || math.IsNaN(n) || math.IsInf(n, 0) {
+			return nil, fmt.Errorf("%s には数値を指定してください: %s", def.Name, value)
+		}
+	case api.CustomFieldDate:
+		if _, err := time.Parse(time.DateOnly, value); err != nil {
+			return nil, fmt.Errorf("%s には日付（yyyy-MM-dd）を指定してください: %s", def.Name, value)
+		}
+	}
+	if !def.IsList() {
+		return []string{value}, nil
+	}
+
+	names := []string{value}
+	if def.IsMultiple() {
+		names = strings.Split(value, ",")
+	}
+	ids, err := customFieldItemIDs(def, names)
+	if err != nil {
+		return nil, err
+	}
+	values := make([]string, len(ids))
+	for i, id := range ids {
+		values[i] = strconv.Itoa(id)
+	}
+	return values, nil
+}
+
+// CustomFieldFilters converts field arguments into issue list filters.
+// Text fields match a keyword, list fields any of the given items, and
+// number and date fields either a value or a range such as 1..5, 3.. or
+// ..2026-03-31.
+func CustomFieldFilters(defs []api.CustomField, args []FieldArg) ([]api.CustomFieldFilter, error) {
+	var filters []api.CustomFieldFilter
+	for _, arg := range args {
+		def, err := FindCustomField(defs, arg.Name)
+		if err != nil {
+			return nil, err
+		}
+		if arg.Value == "" {
+			return nil, fmt.Errorf("%s の値を指定してください", def.Name)
+		}
+
+		f := api.CustomFieldFilter{ID: def.ID}
+		switch {
+		case def.IsList():
+			f.ItemIDs, err = customFieldItemIDs(*def, strings.Split(arg.Value, ","))
+			if err != nil {
+				return nil, err
+			}
+		case def.TypeID == api.CustomFieldNumber || def.TypeID == api.CustomFieldDate:
+			lo, hi, isRange := strings.Cut(arg.Value, "..")
+			if !isRange {
+				hi = lo
+			}
+			for _, v := range []string{lo, hi} {
+				if v == "" {
+					continue
+				}
+				if _, err := CustomFieldValues(*def, v); err != nil {
+					return nil, err
+				}
+			}
+			f.Min, f.Max = lo, hi
+		default:
+			f.Keyword = arg.Value
+		}
+		filters = append(filters, f)
+	}
+	return filters, nil
+}
+
+func customFieldItemIDs(def api.CustomField, names []string) ([]int, error) {
+	var ids []int
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		found := false
+		for _, item := range def.Items {
+			if item.Name == name {
+				ids = append(ids, item.ID)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("%s に「%s」という選択肢はありません", def.Name, name)
+		}
+	}
+	return ids, nil
+}
@@ -0,0 +1,117 @@
+package cmdutil
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/KimMaru10/bl-cli/internal/api"
+)
+
+var testCustomFields = []api.CustomField{
+	{ID: 1, TypeID: api.CustomFieldText, Name: "メモ"},
+	{ID: 2, TypeID: api.CustomFieldNumber, Name: "工数"},
+	{ID: 3, TypeID: api.CustomFieldDate, Name: "リリース日"},
+	{ID: 4, TypeID: api.CustomFieldSingleList, Name: "環境", Items: []api.CustomFieldItem{{ID: 41, Name: "本番"}, {ID: 42, Name: "検証"}}},
+	{ID: 5, TypeID: api.CustomFieldCheckBox, Name: "OS", Items: []api.CustomFieldItem{{ID: 51, Name: "Windows"}, {ID: 52, Name: "macOS"}}},
+}
+
+func TestParseFieldArgs(t *testing.T) {
+	got, err := ParseFieldArgs([]string{"メモ=a=b", " 工数 = 3 ", "OS="})
+	if err != nil {
+		t.Fatalf("ParseFieldArgs() error = %v", err)
+	}
+	want := []FieldArg{{"メモ", "a=b"}, {"工数", "3"}, {"OS", ""}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseFieldArgs() = %+v, want %+v", got, want)
+	}
+
+	for _, arg := range []string{"メモ", "=3"} {
+		if _, err := ParseFieldArgs([]string{arg}); err == nil {
+			t.Errorf("ParseFieldArgs(%q) succeeded, want error", arg)
+		}
+	}
+}
+
+func TestCustomFieldValues(t *testing.T) {
+	tests := []struct {
+		field   string
+		value   string
+		want    []string
+		wantErr string
+	}{
+		{"メモ", "自由入力", []string{"自由入力"}, ""},
+		{"メモ", "", nil, ""},
+		{"工数", "1.5", []string{"1.5"}, ""},
+		{"工数", "abc", nil, "工数 には数値を指定してください"},
+		{"工数", "NaN", nil, "工数 には数値を指定してください"},
+		{"工数", "Inf", nil, "工数 には数値を指定してください"},
+		{"リリース日", "2026-11-01", []string{"2026-11-01"}, ""},
+		{"リリース日", "2026/11/01", nil, "リリース日 には日付（yyyy-MM-dd）を指定してください"},
+		{"環境", "検証", []string{"42"}, ""},
+		// A single list takes the value as one item name.
+		{"環境", "本番,検証", nil, "環境 に「本番,検証」という選択肢はありません"},
+		{"OS", "macOS, Windows", []string{"52", "51"}, ""},
+		{"OS", "Linux", nil, "OS に「Linux」という選択肢はありません"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.field+"="+tt.value, func(t *testing.T) {
+			def, err := FindCustomField(testCustomFields, tt.field)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := CustomFieldValues(*def, tt.value)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("CustomFieldValues() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("CustomFieldValues() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CustomFieldValues() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCustomFieldFilters(t *testing.T) {
+	tests := []struct {
+		arg     FieldArg
+		want    api.CustomFieldFilter
+		wantErr string
+	}{
+		{FieldArg{"メモ", "障害"}, api.CustomFieldFilter{ID: 1, Keyword: "障害"}, ""},
+		{FieldArg{"工数", "3"}, api.CustomFieldFilter{ID: 2, Min: "3", Max: "3"}, ""},
+		{FieldArg{"工数", "1..5"}, api.CustomFieldFilter{ID: 2, Min: "1", Max: "5"}, ""},
+		{FieldArg{"工数", "3.."}, api.CustomFieldFilter{ID: 2, Min: "3"}, ""},
+		{FieldArg{"リリース日", "..2026-03-31"}, api.CustomFieldFilter{ID: 3, Max: "2026-03-31"}, ""},
+		// Unlike when setting a value, a filter matches any of several items.
+		{FieldArg{"環境", "本番,検証"}, api.CustomFieldFilter{ID: 4, ItemIDs: []int{41, 42}}, ""},
+		{FieldArg{"OS", "Windows"}, api.CustomFieldFilter{ID: 5, ItemIDs: []int{51}}, ""},
+		{FieldArg{"工数", "1..x"}, api.CustomFieldFilter{}, "工数 には数値を指定してください"},
+		{FieldArg{"工数", "NaN.."}, api.CustomFieldFilter{}, "工数 には数値を指定してください"},
+		{FieldArg{"工数", ""}, api.CustomFieldFilter{}, "工数 の値を指定してください"},
+		{FieldArg{"環境", "開発"}, api.CustomFieldFilter{}, "環境 に「開発」という選択肢はありません"},
+		{FieldArg{"担当部署", "営業"}, api.CustomFieldFilter{}, "カスタム属性「担当部署」が見つかりません"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.arg.Name+"="+tt.arg.Value, func(t *testing.T) {
+			got, err := CustomFieldFilters(testCustomFields, []FieldArg{tt.arg})
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("CustomFieldFilters() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("CustomFieldFilters() error = %v", err)
+			}
+			if len(got) != 1 || !reflect.DeepEqual(got[0], tt.want) {
+				t.Errorf("CustomFieldFilters() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package cmdutil
+
+import (
+	"errors"
+
+	"github.com/KimMaru10/bl-cli/internal/api"
+)
+
+// ErrNotLoggedIn is returned when no credentials are configured.
+var ErrNotLoggedIn = errors.New("未認証です。bl auth login を先に実行してください")
+
+// Exit codes returned by bl.
+const (
+	ExitOK             = 0
+	ExitError          = 1
+	ExitInvalidRequest = 2
+	ExitNotFound       = 3
+	ExitAuth           = 4
+	ExitForbidden      = 5
+	ExitRateLimited    = 6
+)
+
+// ExitCode maps an error to the process exit code.
+func ExitCode(err error) int {
+	switch {
+	case err == nil:
+		return ExitOK
+	case errors.Is(err, ErrNotLoggedIn), errors.Is(err, api.ErrUnauthorized):
+		return ExitAuth
+	case errors.Is(err, api.ErrForbidden):
+		return ExitForbidden
+	case errors.Is(err, api.ErrNotFound):
+		return ExitNotFound
+	case errors.Is(err, api.ErrRateLimited):
+		return ExitRateLimited
+	case errors.Is(err, api.ErrInvalidRequest):
+		return ExitInvalidRequest
+	default:
+		return ExitError
+	}
+}
+
+// Hint returns a user-facing suggestion for resolving err,
+// or an empty string if there is none.
+func Hint(err error) string {
+	switch {
+	case errors.Is(err, api.ErrUnauthorized):
+		return "API キーが無効か失効しています。bl auth login で再認証してください"
+	case errors.Is(err, api.ErrForbidden):
+		return "この操作を行う権限がありません。プロジェクトへの参加状況と権限を確認してください"
+	case errors.Is(err, api.ErrNotFound):
+		return "課題キーやプロジェクトキーが正しいか確認してください"
+	case errors.Is(err, api.ErrRateLimited):
+		return "API のレート制限に達しました。しばらく待ってから再実行してください（残量は bl api rate-limit で確認できます）"
+	case errors.Is(err, api.ErrInvalidRequest):
+		return "指定した値が正しいか確認してください"
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,49 @@
+package cmdutil
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/KimMaru10/bl-cli/internal/api"
+)
+
+func TestExitCode(t *testing.T) {
+	apiErr := func(status int, codes ...int) error {
+		e := &api.Error{StatusCode: status}
+		for _, code := range codes {
+			e.Errors = append(e.Errors, api.BacklogError{Code: code})
+		}
+		return fmt.Errorf("課題の取得に失敗しました: %w", e)
+	}
+	tests := []struct {
+		name     string
+		err      error
+		want     int
+		wantHint bool
+	}{
+		{"nil", nil, ExitOK, false},
+		{"plain error", errors.New("x"), ExitError, false},
+		{"not logged in", ErrNotLoggedIn, ExitAuth, false},
+		{"401", apiErr(401), ExitAuth, true},
+		{"authentication error code", apiErr(400, api.CodeAuthenticationError), ExitAuth, true},
+		{"403", apiErr(403), ExitForbidden, true},
+		{"404", apiErr(404), ExitNotFound, true},
+		{"no resource code", apiErr(400, api.CodeNoResourceError), ExitNotFound, true},
+		{"400", apiErr(400), ExitInvalidRequest, true},
+		{"429", apiErr(429), ExitRateLimited, true},
+		{"too many requests code", apiErr(400, api.CodeTooManyRequestsError), ExitRateLimited, true},
+		{"500", apiErr(500), ExitError, false},
+		{"503", apiErr(503, api.CodeInternalError), ExitError, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExitCode(tt.err); got != tt.want {
+				t.Errorf("ExitCode(%v) = %d, want %d", tt.err, got, tt.want)
+			}
+			if got := Hint(tt.err) != ""; got != tt.wantHint {
+				t.Errorf("Hint(%v) = %q, want a hint: %v", tt.err, Hint(tt.err), tt.wantHint)
+			}
+		})
+	}
+}
@@ -0,0 +1,219 @@
+package cmdutil
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"slices"
+	"strings"
+	"text/template"
+
+	"github.com/itchyny/gojq"
+	"github.com/spf13/cobra"
+)
+
+// Exporter renders command results as JSON instead of styled text.
+// Output can be narrowed with --json fields, filtered with a jq
+// expression or rendered with a Go text/template.
+type Exporter struct {
+	fields   []string
+	jq       string
+	template string
+	allowed  []string
+}
+
+// AddJSONFlags registers --json, --jq and --template on cmd.
+// allowed lists the field names that can be selected with --json.
+func AddJSONFlags(cmd *cobra.Command, allowed []string) *Exporter {
+	e := AddFormatFlags(cmd)
+	e.allowed = allowed
+	cmd.Flags().StringSliceVar(&e.fields, "json", nil, "JSON で出力するフィールド（"+strings.Join(allowed, ", ")+"）")
+	return e
+}
+
+// AddFormatFlags registers only --jq and --template on cmd, for commands
+// whose output is already JSON and has no fixed set of fields.
+func AddFormatFlags(cmd *cobra.Command) *Exporter {
+	e := &Exporter{}
+	cmd.Flags().StringVarP(&e.jq, "jq", "q", "", "jq 式で JSON 出力を絞り込む")
+	cmd.Flags().StringVarP(&e.template, "template", "t", "", "Go テンプレートで JSON 出力を整形する")
+	return e
+}
+
+// Enabled reports whether any structured output flag was given.
+func (e *Exporter) Enabled() bool {
+	return len(e.fields) > 0 || e.jq != "" || e.template != ""
+}
+
+// Write serializes v and writes it to w according to the flags.
+func (e *Exporter) Write(w io.Writer, v any) error {
+	for _, f := range e.fields {
+		if !slices.Contains(e.allowed, f) {
+			return fmt.Errorf("不明なフィールドです: %s（利用可能: %s）", f, strings.Join(e.allowed, ", "))
+		}
+	}
+
+	data, err := toGeneric(v)
+	if err != nil {
+		return err
+	}
+	if len(e.fields) > 0 {
+		data = selectFields(data, e.fields)
+	}
+
+	switch {
+	case e.jq != "":
+		return writeJQ(w, e.jq, data)
+	case e.template != "":
+		return writeTemplate(w, e.template, data)
+	default:
+		out, err := json.MarshalIndent(data, "", "  ")
+		if err != nil {
+			return fmt.Errorf("JSON のシリアライズに失敗しました: %w", err)
+		}
+		_, err = fmt.Fprintln(w, string(out))
+		return err
+	}
+}
+
+// StructFields returns the JSON field names of a struct value,
+// in declaration order. The fields of untagged embedded structs are
+// included where they are embedded, as encoding/json promotes them.
+func StructFields(v any) []string {
+	return structFields(reflect.TypeOf(v))
+}
+
+func structFields(t reflect.Type) []string {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	var fields []string
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "" && f.Anonymous {
+			fields = append(fields, structFields(f.Type)...)
+			continue
+		}
+		if name != "" && name != "-" {
+			fields = append(fields, name)
+		}
+	}
+	return fields
+}
+
+// toGeneric round-trips v through JSON so that field selection,
+// jq and templates all see the same map/slice representation.
+func toGeneric(v any) (any, error) {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("JSON のシリアライズに失敗しました: %w", err)
+	}
+	var data any
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	if err := dec.Decode(&data); err != nil {
+		return nil, fmt.Errorf("JSON の解析に失敗しました: %w", err)
+	}
+	return normalizeNumbers(data), nil
+}
+
+// normalizeNumbers converts json.Number into int or float64, which
+// gojq requires.
+func normalizeNumbers(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for k, x := range v {
+			v[k] = normalizeNumbers(x)
+		}
+		return v
+	case []any:
+		for i, x := range v {
+			v[i] = normalizeNumbers(x)
+		}
+		return v
+	case json.Number:
+		if n, err := v.Int64(); err == nil {
+			return int(n)
+		}
+		f, _ := v.Float64()
+		return f
+	default:
+		return v
+	}
+}
+
+func selectFields(v any, fields []string) any {
+	switch v := v.(type) {
+	case []any:
+		out := make([]any, len(v))
+		for i, x := range v {
+			out[i] = selectFields(x, fields)
+		}
+		return out
+	case map[string]any:
+		out := make(map[string]any, len(fields))
+		for _, f := range fields {
+			out[f] = v[f]
+		}
+		return out
+	default:
+		return v
+	}
+}
+
+func writeJQ(w io.Writer, expr string, data any) error {
+	query, err := gojq.Parse(expr)
+	if err != nil {
+		return fmt.Errorf("jq 式の解析に失敗しました: %w", err)
+	}
+	iter := query.Run(data)
+	for {
+		v, ok := iter.Next()
+		if !ok {
+			return nil
+		}
+		if err, ok := v.(error); ok {
+			return fmt.Errorf("jq 式の実行に失敗しました: %w", err)
+		}
+		// Print strings raw, like gh --jq
+		if s, ok := v.(string); ok {
+			fmt.Fprintln(w, s)
+			continue
+		}
+		out, err := gojq.Marshal(v)
+		if err != nil {
+			return fmt.Errorf("JSON のシリアライズに失敗しました: %w", err)
+		}
+		fmt.Fprintln(w, string(out))
+	}
+}
+
+var templateFuncs = template.FuncMap{
+	"json": func(v any) (string, error) {
+		out, err := json.Marshal(v)
+		return string(out), err
+	},
+	// join takes any so that a null or missing field joins to "".
+	"join": func(sep string, v any) string {
+		items, _ := v.([]any)
+		parts := make([]string, len(items))
+		for i, x := range items {
+			parts[i] = fmt.Sprint(x)
+		}
+		return strings.Join(parts, sep)
+	},
+}
+
+func writeTemplate(w io.Writer, text string, data any) error {
+	tmpl, err := template.New("output").Funcs(templateFuncs).Parse(text)
+	if err != nil {
+		return fmt.Errorf("テンプレートの解析に失敗しました: %w", err)
+	}
+	if err := tmpl.Execute(w, data); err != nil {
+		return fmt.Errorf("テンプレートの実行に失敗しました: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,139 @@
+package cmdutil
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+type exportItem struct {
+	ID     int      `json:"id"`
+	Key    string   `json:"issueKey"`
+	Hours  float64  `json:"hours"`
+	Labels []string `json:"labels"`
+	Secret string   `json:"-"`
+}
+
+var exportItems = []exportItem{
+	{ID: 1, Key: "PROJ-1", Hours: 1.5, Labels: []string{"a", "b"}, Secret: "x"},
+	{ID: 2, Key: "PROJ-2", Hours: 2, Labels: nil},
+}
+
+func TestExporterWrite(t *testing.T) {
+	allowed := StructFields(exportItem{})
+	tests := []struct {
+		name    string
+		e       Exporter
+		want    string
+		wantErr string
+	}{
+		{
+			name: "fields",
+			e:    Exporter{fields: []string{"issueKey"}, allowed: allowed},
+			want: "[\n  {\n    \"issueKey\": \"PROJ-1\"\n  },\n  {\n    \"issueKey\": \"PROJ-2\"\n  }\n]\n",
+		},
+		{
+			name:    "unknown field",
+			e:       Exporter{fields: []string{"Secret"}, allowed: allowed},
+			wantErr: "不明なフィールドです: Secret",
+		},
+		{
+			name: "jq prints strings raw",
+			e:    Exporter{jq: ".[].issueKey"},
+			want: "PROJ-1\nPROJ-2\n",
+		},
+		{
+			name: "jq keeps integers",
+			e:    Exporter{jq: ".[] | {id, hours}"},
+			want: "{\"hours\":1.5,\"id\":1}\n{\"hours\":2,\"id\":2}\n",
+		},
+		{
+			name: "jq after fields",
+			e:    Exporter{fields: []string{"id"}, allowed: allowed, jq: "map(.id) | add"},
+			want: "3\n",
+		},
+		{
+			name: "jq empty result",
+			e:    Exporter{jq: ".[] | select(.id > 5)"},
+			want: "",
+		},
+		{
+			name:    "jq syntax error",
+			e:       Exporter{jq: ".[] |"},
+			wantErr: "jq 式の解析に失敗しました",
+		},
+		{
+			name:    "jq runtime error",
+			e:       Exporter{jq: ".[0].issueKey + 1"},
+			wantErr: "jq 式の実行に失敗しました",
+		},
+		{
+			name: "template",
+			e:    Exporter{template: `{{range .}}{{.issueKey}}	{{.id}}	{{.hours}}{{"\n"}}{{end}}`},
+			want: "PROJ-1\t1\t1.5\nPROJ-2\t2\t2\n",
+		},
+		{
+			name: "template funcs",
+			e:    Exporter{template: `{{range .}}{{join "," .labels}}|{{json .labels}}{{"\n"}}{{end}}`},
+			want: "a,b|[\"a\",\"b\"]\n|null\n",
+		},
+		{
+			name:    "template syntax error",
+			e:       Exporter{template: "{{range .}}"},
+			wantErr: "テンプレートの解析に失敗しました",
+		},
+		{
+			name:    "template runtime error",
+			e:       Exporter{template: "{{.issueKey}}"},
+			wantErr: "テンプレートの実行に失敗しました",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			err := tt.e.Write(&buf, exportItems)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Write() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Write() error = %v", err)
+			}
+			if got := buf.String(); got != tt.want {
+				t.Errorf("Write() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStructFields(t *testing.T) {
+	got := strings.Join(StructFields(&exportItem{}), ",")
+	if want := "id,issueKey,hours,labels"; got != want {
+		t.Errorf("StructFields() = %s, want %s", got, want)
+	}
+}
+
+func TestStructFieldsEmbedded(t *testing.T) {
+	type embedded struct {
+		Source string `json:"source"`
+		exportItem
+		Extra []string `json:"extra"`
+	}
+	got := strings.Join(StructFields(embedded{}), ",")
+	if want := "source,id,issueKey,hours,labels,extra"; got != want {
+		t.Errorf("StructFields() = %s, want %s", got, want)
+	}
+}
+
+func TestExporterEnabled(t *testing.T) {
+	if (&Exporter{}).Enabled() {
+		t.Error("Enabled() = true without flags")
+	}
+	for _, e := range []Exporter{{fields: []string{"id"}}, {jq: "."}, {template: "x"}} {
+		if !e.Enabled() {
+			t.Errorf("Enabled() = false for %+v", e)
+		}
+	}
+}
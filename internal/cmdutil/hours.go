@@ -0,0 +1,70 @@
+package cmdutil
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/KimMaru10/bl-cli/internal/api"
+)
+
+// maxHours is the largest duration ParseHours accepts, well above any
+// real estimate or work log, so that a typo such as 1e9 is caught.
+const maxHours = 10000
+
+// ParseHours parses a duration given as hours. It accepts plain numbers
+// ("1.5"), hour and minute suffixes ("1.5h", "90m", "1h30m") and the
+// Japanese units 時間 and 分.
+func ParseHours(s string) (float64, error) {
+	in := strings.TrimSpace(s)
+	normalized := strings.NewReplacer("時間", "h", "分", "m").Replace(strings.ToLower(in))
+	if normalized == "" {
+		return 0, fmt.Errorf("時間を指定してください")
+	}
+
+	h, err := strconv.ParseFloat(normalized, 64)
+	if err != nil {
+		d, err := time.ParseDuration(normalized)
+		if err != nil {
+			return 0, fmt.Errorf("時間の形式が正しくありません（例: 1.5h, 90m, 1h30m）: %s", in)
+		}
+		h = d.Hours()
+	}
+	switch {
+	case math.IsNaN(h) || math.IsInf(h, 0):
+		return 0, fmt.Errorf("時間の形式が正しくありません（例: 1.5h, 90m, 1h30m）: %s", in)
+	case h < 0:
+		return 0, fmt.Errorf("時間には正の値を指定してください: %s", in)
+	case h > maxHours:
+		return 0, fmt.Errorf("時間は %d 時間以下で指定してください: %s", maxHours, in)
+	}
+	return h, nil
+}
+
+// FormatHours formats hours for display, e.g. 1.5h. Backlog keeps two
+// decimal places, so the value is rounded to match.
+func FormatHours(h float64) string {
+	return strconv.FormatFloat(math.Round(h*100)/100, 'f', -1, 64) + "h"
+}
+
+// LogTime adds hours to the actual hours of an issue, with an optional
+// comment, and returns the updated issue.
+func LogTime(ctx context.Context, client *api.Client, issueKey string, hours float64, comment string) (*api.Issue, error) {
+	issue, err := client.GetIssue(ctx, issueKey)
+	if err != nil {
+		return nil, err
+	}
+
+	actual := hours
+	if issue.ActualHours != nil {
+		actual += *issue.ActualHours
+	}
+	opts := &api.UpdateIssueOptions{ActualHours: &actual}
+	if comment != "" {
+		opts.Comment = &comment
+	}
+	return client.UpdateIssue(ctx, issueKey, opts)
+}
@@ -0,0 +1,71 @@
+package cmdutil
+
+import "testing"
+
+func TestParseHours(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    float64
+		wantErr bool
+	}{
+		{"1.5", 1.5, false},
+		{" 2 ", 2, false},
+		{"0", 0, false},
+		{"1.5h", 1.5, false},
+		{"90m", 1.5, false},
+		{"1h30m", 1.5, false},
+		{"1H30M", 1.5, false},
+		{"2時間", 2, false},
+		{"1時間30分", 1.5, false},
+		{"45分", 0.75, false},
+		{"10000", 10000, false},
+		{"", 0, true},
+		{"abc", 0, true},
+		{"-1", 0, true},
+		{"-30m", 0, true},
+		{"nan", 0, true},
+		{"NaN", 0, true},
+		{"inf", 0, true},
+		{"+Inf", 0, true},
+		{"-inf", 0, true},
+		{"1e9", 0, true},
+		{"10001", 0, true},
+		{"10001h", 0, true},
+		{"1.5d", 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseHours(tt.in)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ParseHours(%q) = %v, want error", tt.in, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseHours(%q) error = %v", tt.in, err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseHours(%q) = %v, want %v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatHours(t *testing.T) {
+	tests := []struct {
+		in   float64
+		want string
+	}{
+		{0, "0h"},
+		{1.5, "1.5h"},
+		{2, "2h"},
+		{1.0 / 3, "0.33h"},
+		{0.755, "0.76h"},
+	}
+	for _, tt := range tests {
+		if got := FormatHours(tt.in); got != tt.want {
+			t.Errorf("FormatHours(%v) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
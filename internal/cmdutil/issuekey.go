@@ -0,0 +1,80 @@
+package cmdutil
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/KimMaru10/bl-cli/internal/api"
+	"github.com/KimMaru10/bl-cli/internal/config"
+	"github.com/KimMaru10/bl-cli/internal/git"
+	"github.com/KimMaru10/bl-cli/internal/tui"
+)
+
+// IssueKeyMatcher builds the issue key matcher from the issue_key
+// settings in the config and .bl.yaml.
+func IssueKeyMatcher(cfg *config.Config) (*git.KeyMatcher, error) {
+	rules := cfg.IssueKeyRules()
+	return git.NewKeyMatcher(rules.Patterns, rules.IgnoreCase, rules.Projects)
+}
+
+// ResolveIssueKey resolves an issue key from args, or else from the
+// current git branch, its upstream branch or the latest commit message.
+// When several keys are found the user picks one if running in a
+// terminal; otherwise the key must be given. A key found only in the
+// latest commit message may belong to finished work, so it is used only
+// after the user confirms it in a terminal.
+func ResolveIssueKey(cfg *config.Config, args []string) (string, error) {
+	if len(args) > 0 {
+		return args[0], nil
+	}
+
+	m, err := IssueKeyMatcher(cfg)
+	if err != nil {
+		return "", err
+	}
+	keys, source := git.FindIssueKeys(m)
+	if len(keys) == 0 {
+		return "", fmt.Errorf("課題キーを指定するか、課題キーを含むブランチに切り替えてください")
+	}
+	fromCommit := source == git.KeyFromCommit
+	if len(keys) == 1 && !fromCommit {
+		return keys[0], nil
+	}
+	if !tui.Interactive() {
+		if fromCommit {
+			return "", fmt.Errorf("課題キーが直前のコミットメッセージにしか見つかりません（候補: %s）。課題キーを指定してください", strings.Join(keys, ", "))
+		}
+		return "", fmt.Errorf("課題キーを特定できません（候補: %s）。課題キーを指定してください", strings.Join(keys, ", "))
+	}
+	if len(keys) == 1 {
+		if !tui.Confirm(fmt.Sprintf("直前のコミットメッセージにある %s を対象にしますか？", keys[0])) {
+			return "", fmt.Errorf("課題キーを指定してください")
+		}
+		return keys[0], nil
+	}
+
+	items := make([]tui.SelectItem, len(keys))
+	for i, k := range keys {
+		items[i] = tui.SelectItem{ID: i, Label: k}
+	}
+	selected := tui.Select("課題を選択", items)
+	if selected == nil {
+		return "", fmt.Errorf("課題キーの選択がキャンセルされました")
+	}
+	return keys[selected.ID], nil
+}
+
+// IssueProjectKey returns the project key of an issue given by key or by
+// numeric ID. The issue is fetched only when the key does not carry it.
+func IssueProjectKey(ctx context.Context, client *api.Client, issueIDOrKey string) (string, error) {
+	if projectKey, _, ok := strings.Cut(issueIDOrKey, "-"); ok && projectKey != "" {
+		return projectKey, nil
+	}
+	issue, err := client.GetIssue(ctx, issueIDOrKey)
+	if err != nil {
+		return "", err
+	}
+	projectKey, _, _ := strings.Cut(issue.IssueKey, "-")
+	return projectKey, nil
+}
@@ -0,0 +1,64 @@
+package cmdutil
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/KimMaru10/bl-cli/internal/api"
+	"github.com/KimMaru10/bl-cli/internal/config"
+	"github.com/KimMaru10/bl-cli/internal/oauth"
+	"golang.org/x/oauth2"
+)
+
+// persistingTokenSource refreshes the token when it expires and saves
+// the new one, so the next invocation starts from the new refresh token.
+type persistingTokenSource struct {
+	alias string
+	conf  *oauth2.Config
+
+	mu    sync.Mutex
+	creds config.OAuthCredentials
+	tok   *oauth2.Token
+}
+
+func newTokenSource(alias string, space *config.SpaceConfig) api.TokenSource {
+	creds := *space.OAuth
+	return &persistingTokenSource{
+		alias: alias,
+		conf:  oauth.Config(space.SpaceURL, creds.ClientID, creds.ClientSecret, oauth.DefaultRedirectPort),
+		creds: creds,
+		tok: &oauth2.Token{
+			AccessToken:  creds.AccessToken,
+			RefreshToken: creds.RefreshToken,
+			Expiry:       creds.Expiry,
+			TokenType:    "Bearer",
+		},
+	}
+}
+
+// Token returns the current token, refreshing it with ctx if it has
+// expired. Concurrent callers wait for a single refresh.
+func (s *persistingTokenSource) Token(ctx context.Context) (*oauth2.Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.tok.Valid() {
+		return s.tok, nil
+	}
+
+	tok, err := s.conf.TokenSource(ctx, s.tok).Token()
+	if err != nil {
+		return nil, err
+	}
+	s.tok = tok
+	if tok.AccessToken != s.creds.AccessToken {
+		s.creds.AccessToken = tok.AccessToken
+		s.creds.RefreshToken = tok.RefreshToken
+		s.creds.Expiry = tok.Expiry
+		if err := config.UpdateOAuthToken(s.alias, &s.creds); err != nil {
+			fmt.Fprintln(os.Stderr, "警告: 更新した OAuth トークンの保存に失敗しました: "+err.Error())
+		}
+	}
+	return tok, nil
+}
@@ -0,0 +1,101 @@
+package cmdutil
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/KimMaru10/bl-cli/internal/config"
+)
+
+func TestPersistingTokenSourceRefresh(t *testing.T) {
+	var refreshes atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Errorf("ParseForm: %v", err)
+		}
+		if got := r.PostForm.Get("grant_type"); got != "refresh_token" {
+			t.Errorf("grant_type = %q, want refresh_token", got)
+		}
+		if got := r.PostForm.Get("refresh_token"); got != "old-refresh" {
+			t.Errorf("refresh_token = %q, want old-refresh", got)
+		}
+		refreshes.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{
+			"access_token":  "new-access",
+			"refresh_token": "new-refresh",
+			"token_type":    "Bearer",
+			"expires_in":    3600,
+		})
+	}))
+	defer srv.Close()
+
+	t.Setenv(config.EnvConfigDir, t.TempDir())
+	t.Setenv(config.EnvSpaceURL, "")
+	t.Setenv(config.EnvAPIKey, "")
+	space := config.SpaceConfig{
+		SpaceURL: srv.URL,
+		AuthType: config.AuthOAuth,
+		OAuth: &config.OAuthCredentials{
+			ClientID:     "client",
+			ClientSecret: "secret",
+			AccessToken:  "old-access",
+			RefreshToken: "old-refresh",
+			Expiry:       time.Now().Add(-time.Hour),
+		},
+	}
+	if err := config.Save(&config.Config{CurrentSpace: "main", Spaces: map[string]config.SpaceConfig{"main": space}}); err != nil {
+		t.Fatal(err)
+	}
+
+	ts := newTokenSource("main", &space)
+	for range 2 {
+		tok, err := ts.Token(context.Background())
+		if err != nil {
+			t.Fatalf("Token() error = %v", err)
+		}
+		if tok.AccessToken != "new-access" {
+			t.Errorf("AccessToken = %q, want new-access", tok.AccessToken)
+		}
+	}
+	if n := refreshes.Load(); n != 1 {
+		t.Errorf("refreshed %d times, want 1", n)
+	}
+
+	cfg, err := config.LoadGlobal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	saved := cfg.Spaces["main"].OAuth
+	if saved == nil || saved.AccessToken != "new-access" || saved.RefreshToken != "new-refresh" {
+		t.Errorf("saved credentials = %+v, want the refreshed token", saved)
+	}
+}
+
+func TestPersistingTokenSourceCancelled(t *testing.T) {
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer srv.Close()
+	defer close(release)
+
+	space := &config.SpaceConfig{
+		SpaceURL: srv.URL,
+		AuthType: config.AuthOAuth,
+		OAuth: &config.OAuthCredentials{
+			RefreshToken: "old-refresh",
+			Expiry:       time.Now().Add(-time.Hour),
+		},
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	if _, err := newTokenSource("main", space).Token(ctx); err == nil {
+		t.Fatal("Token() succeeded, want the refresh to be cancelled with ctx")
+	}
+}
@@ -0,0 +1,22 @@
+package cmdutil
+
+import (
+	"context"
+
+	"github.com/KimMaru10/bl-cli/internal/api"
+)
+
+// NotifiedUserIDs resolves the names to notify and the @mentions in text
+// to the IDs of the members of a project.
+func NotifiedUserIDs(ctx context.Context, client *api.Client, projectIDOrKey string, names []string, text string) ([]int, error) {
+	members, err := client.GetProjectUsers(ctx, projectIDOrKey)
+	if err != nil {
+		return nil, err
+	}
+	notified, err := api.FindUsers(members, names)
+	if err != nil {
+		return nil, err
+	}
+	notified = append(notified, api.MentionedUsers(members, text)...)
+	return api.UserIDs(notified), nil
+}
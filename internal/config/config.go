@@ -5,29 +5,202 @@ import (
 	"os"
 	"path/filepath"
 	"sort"
+	"time"
 
 	"go.yaml.in/yaml/v3"
 )
 
+// Authentication methods of a space.
+const (
+	AuthAPIKey = ""
+	AuthOAuth  = "oauth"
+)
+
 // SpaceConfig holds credentials and settings for a single Backlog space.
+// APIKey and OAuth are empty when the credentials are kept in a secret
+// store; call LoadCredentials to fetch them.
 type SpaceConfig struct {
-	SpaceURL       string `yaml:"space_url"`
-	APIKey         string `yaml:"api_key"`
-	DefaultProject string `yaml:"default_project,omitempty"`
+	SpaceURL         string            `yaml:"space_url"`
+	AuthType         string            `yaml:"auth_type,omitempty"`
+	APIKey           string            `yaml:"api_key,omitempty"`
+	OAuth            *OAuthCredentials `yaml:"oauth,omitempty"`
+	DefaultProject   string            `yaml:"default_project,omitempty"`
+	CredentialStore  string            `yaml:"credential_store,omitempty"`
+	CredentialHelper string            `yaml:"credential_helper,omitempty"`
+
+	// Projects holds per-project settings keyed by project key.
+	Projects map[string]ProjectConfig `yaml:"projects,omitempty"`
+}
+
+// ProjectConfig holds settings for a single project. The status fields
+// name the statuses used by bl issue close, reopen and resolve; when empty
+// they default to 完了, 未対応 and 処理済み (or the last, first and
+// second-to-last status if those have been removed).
+type ProjectConfig struct {
+	CloseStatus   string `yaml:"close_status,omitempty"`
+	ReopenStatus  string `yaml:"reopen_status,omitempty"`
+	ResolveStatus string `yaml:"resolve_status,omitempty"`
+}
+
+// OAuthCredentials holds the OAuth 2.0 client and token of a space.
+type OAuthCredentials struct {
+	ClientID     string    `yaml:"client_id" json:"client_id"`
+	ClientSecret string    `yaml:"client_secret" json:"client_secret"`
+	AccessToken  string    `yaml:"access_token" json:"access_token"`
+	RefreshToken string    `yaml:"refresh_token" json:"refresh_token"`
+	Expiry       time.Time `yaml:"expiry" json:"expiry"`
+}
+
+// Authenticated reports whether s holds usable credentials.
+func (s *SpaceConfig) Authenticated() bool {
+	if s.AuthType == AuthOAuth {
+		return s.OAuth != nil && s.OAuth.AccessToken != ""
+	}
+	return s.APIKey != ""
 }
 
+// Environment variables that override the config file.
+const (
+	EnvConfigDir = "BL_CONFIG_DIR"
+	EnvSpace     = "BL_SPACE"
+	EnvSpaceURL  = "BL_SPACE_URL"
+	EnvAPIKey    = "BL_API_KEY"
+	EnvProject   = "BL_PROJECT"
+)
+
 // Config holds the application configuration supporting multiple spaces.
 type Config struct {
 	CurrentSpace string                 `yaml:"current_space"`
 	Spaces       map[string]SpaceConfig `yaml:"spaces"`
+	IssueKey     *IssueKeyConfig        `yaml:"issue_key,omitempty"`
+	Views        map[string]View        `yaml:"views,omitempty"`
+
+	// spaceOverride is set by UseSpace and never saved.
+	spaceOverride string
+	// local is the .bl.yaml of the current repository, if any.
+	local *LocalConfig
+}
+
+// IssueKeyConfig controls how issue keys are found in branch names and
+// commit messages.
+type IssueKeyConfig struct {
+	// Patterns are regular expressions matching an issue key. If one has
+	// a capture group, the group is used as the key.
+	Patterns   []string `yaml:"patterns,omitempty"`
+	IgnoreCase bool     `yaml:"ignore_case,omitempty"`
+	// Projects limits matches to keys of these projects.
+	Projects []string `yaml:"projects,omitempty"`
+}
+
+// IssueKeyRules returns the issue key settings, with those in .bl.yaml
+// taking precedence over the global config field by field.
+func (c *Config) IssueKeyRules() IssueKeyConfig {
+	var rules IssueKeyConfig
+	for _, k := range []*IssueKeyConfig{c.IssueKey, c.Local().IssueKey} {
+		if k == nil {
+			continue
+		}
+		if len(k.Patterns) > 0 {
+			rules.Patterns = k.Patterns
+		}
+		if k.IgnoreCase {
+			rules.IgnoreCase = true
+		}
+		if len(k.Projects) > 0 {
+			rules.Projects = k.Projects
+		}
+	}
+	return rules
+}
+
+// Local returns the settings from .bl.yaml, or an empty LocalConfig if
+// the current directory has none.
+func (c *Config) Local() *LocalConfig {
+	if c.local == nil {
+		return &LocalConfig{}
+	}
+	return c.local
+}
+
+// UseSpace selects a space for this invocation only, taking precedence
+// over BL_SPACE and current_space. The choice is not saved.
+func (c *Config) UseSpace(alias string) error {
+	if _, ok := c.Spaces[alias]; !ok {
+		return fmt.Errorf("スペース '%s' が見つかりません（登録済み: %v）", alias, c.SpaceNames())
+	}
+	c.spaceOverride = alias
+	return nil
+}
+
+// CurrentName returns the alias of the active space. The --space flag
+// (UseSpace) wins over BL_SPACE, then the space in .bl.yaml, then
+// current_space.
+func (c *Config) CurrentName() string {
+	if c.spaceOverride != "" {
+		return c.spaceOverride
+	}
+	if alias := os.Getenv(EnvSpace); alias != "" {
+		return alias
+	}
+	if c.local != nil && c.local.Space != "" {
+		return c.local.Space
+	}
+	return c.CurrentSpace
+}
+
+// CheckSpace returns an error when the active alias names no registered
+// space, so that a typo in BL_SPACE or .bl.yaml is not reported as a
+// missing login. The alias is accepted when BL_SPACE_URL supplies the
+// space instead.
+func (c *Config) CheckSpace() error {
+	name := c.CurrentName()
+	if name == "" {
+		return nil
+	}
+	if _, ok := c.Spaces[name]; ok {
+		return nil
+	}
+	if c.spaceOverride == "" && os.Getenv(EnvSpaceURL) != "" {
+		return nil
+	}
+	var source string
+	switch {
+	case c.spaceOverride != "":
+	case os.Getenv(EnvSpace) != "":
+		source = "環境変数 " + EnvSpace + " の"
+	case c.local != nil && c.local.Space != "":
+		source = LocalConfigFile + " の"
+	}
+	return fmt.Errorf("%sスペース '%s' が見つかりません（登録済み: %v）", source, name, c.SpaceNames())
 }
 
 // Current returns the currently active SpaceConfig, or nil if not set.
+// The project in .bl.yaml overrides default_project. BL_SPACE_URL,
+// BL_API_KEY and BL_PROJECT override the values from both files, so
+// credentials can be supplied entirely from the environment. A space
+// chosen with --space (UseSpace) keeps its own URL and credentials.
 func (c *Config) Current() *SpaceConfig {
-	if c.CurrentSpace == "" {
-		return nil
+	s, ok := c.Spaces[c.CurrentName()]
+
+	if c.local != nil && c.local.Project != "" {
+		s.DefaultProject = c.local.Project
+	}
+
+	if c.spaceOverride == "" {
+		if v := os.Getenv(EnvSpaceURL); v != "" {
+			s.SpaceURL = v
+			ok = true
+		}
+		if v := os.Getenv(EnvAPIKey); v != "" {
+			s.AuthType = AuthAPIKey
+			s.APIKey = v
+			s.OAuth = nil
+		}
+	}
+	if v := os.Getenv(EnvProject); v != "" {
+		s.DefaultProject = v
 	}
-	s, ok := c.Spaces[c.CurrentSpace]
+
 	if !ok {
 		return nil
 	}
@@ -44,7 +217,12 @@ func (c *Config) SpaceNames() []string {
 	return names
 }
 
-func configDir() (string, error) {
+// Dir returns the directory holding the config file and other local
+// data, $BL_CONFIG_DIR or ~/.config/bl.
+func Dir() (string, error) {
+	if dir := os.Getenv(EnvConfigDir); dir != "" {
+		return dir, nil
+	}
 	home, err := os.UserHomeDir()
 	if err != nil {
 		return "", fmt.Errorf("ホームディレクトリの取得に失敗しました: %w", err)
@@ -53,7 +231,7 @@ func configDir() (string, error) {
 }
 
 func configPath() (string, error) {
-	dir, err := configDir()
+	dir, err := Dir()
 	if err != nil {
 		return "", err
 	}
@@ -62,7 +240,26 @@ func configPath() (string, error) {
 
 // Load reads the config file and returns a Config.
 // Automatically migrates from the old single-space format.
+// The .bl.yaml of the current repository, if any, is applied on top.
 func Load() (*Config, error) {
+	cfg, err := load()
+	if err != nil {
+		return nil, err
+	}
+	if cfg.local, err = LoadLocal(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// LoadGlobal reads only the global config file, ignoring .bl.yaml, so
+// that a broken repository file cannot block commands such as
+// bl auth login that manage the global config.
+func LoadGlobal() (*Config, error) {
+	return load()
+}
+
+func load() (*Config, error) {
 	p, err := configPath()
 	if err != nil {
 		return nil, err
@@ -115,7 +312,7 @@ func Load() (*Config, error) {
 
 // Save writes the config to the config file.
 func Save(cfg *Config) error {
-	dir, err := configDir()
+	dir, err := Dir()
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,105 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCurrentPrecedence(t *testing.T) {
+	newConfig := func() *Config {
+		return &Config{
+			CurrentSpace: "main",
+			Spaces: map[string]SpaceConfig{
+				"main":  {SpaceURL: "https://main.backlog.com", APIKey: "main-key"},
+				"other": {SpaceURL: "https://other.backlog.com", APIKey: "other-key"},
+			},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		space    string
+		envURL   string
+		envKey   string
+		wantName string
+		wantURL  string
+		wantKey  string
+	}{
+		{"config only", "", "", "", "main", "https://main.backlog.com", "main-key"},
+		{"env overrides current space", "", "https://env.backlog.com", "env-key", "main", "https://env.backlog.com", "env-key"},
+		{"--space wins over env", "other", "https://env.backlog.com", "env-key", "other", "https://other.backlog.com", "other-key"},
+		{"--space without env", "other", "", "", "other", "https://other.backlog.com", "other-key"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(EnvSpace, "")
+			t.Setenv(EnvSpaceURL, tt.envURL)
+			t.Setenv(EnvAPIKey, tt.envKey)
+			t.Setenv(EnvProject, "")
+
+			cfg := newConfig()
+			if tt.space != "" {
+				if err := cfg.UseSpace(tt.space); err != nil {
+					t.Fatalf("UseSpace(%q): %v", tt.space, err)
+				}
+			}
+			if got := cfg.CurrentName(); got != tt.wantName {
+				t.Errorf("CurrentName() = %q, want %q", got, tt.wantName)
+			}
+			s := cfg.Current()
+			if s == nil {
+				t.Fatal("Current() = nil")
+			}
+			if s.SpaceURL != tt.wantURL || s.APIKey != tt.wantKey {
+				t.Errorf("Current() = %s %s, want %s %s", s.SpaceURL, s.APIKey, tt.wantURL, tt.wantKey)
+			}
+		})
+	}
+}
+
+func TestUseSpaceUnknown(t *testing.T) {
+	t.Setenv(EnvSpaceURL, "https://env.backlog.com")
+	cfg := &Config{Spaces: map[string]SpaceConfig{"main": {}}}
+	if err := cfg.UseSpace("nope"); err == nil {
+		t.Error("UseSpace(nope) succeeded, want error")
+	}
+}
+
+func TestCheckSpace(t *testing.T) {
+	tests := []struct {
+		name    string
+		current string
+		local   string
+		env     string
+		envURL  string
+		wantErr string
+	}{
+		{"registered", "main", "", "", "", ""},
+		{"not logged in", "", "", "", "", ""},
+		{"unknown BL_SPACE", "main", "", "typo", "", "環境変数 BL_SPACE のスペース 'typo' が見つかりません（登録済み: [main other]）"},
+		{"BL_SPACE with BL_SPACE_URL", "main", "", "ci", "https://ci.backlog.com", ""},
+		{"unknown .bl.yaml space", "main", "typo", "", "", ".bl.yaml のスペース 'typo' が見つかりません"},
+		{"unknown current_space", "gone", "", "", "", "スペース 'gone' が見つかりません"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(EnvSpace, tt.env)
+			t.Setenv(EnvSpaceURL, tt.envURL)
+			cfg := &Config{
+				CurrentSpace: tt.current,
+				Spaces:       map[string]SpaceConfig{"main": {}, "other": {}},
+				local:        &LocalConfig{Space: tt.local},
+			}
+			err := cfg.CheckSpace()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("CheckSpace() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("CheckSpace() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,184 @@
+package config
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// EnvPassphrase supplies the passphrase of the encrypted credentials file
+// without prompting.
+const EnvPassphrase = "BL_CREDENTIALS_PASSPHRASE"
+
+// PromptPassphrase asks the user for the credentials file passphrase.
+// It is nil when no terminal is available, in which case only
+// BL_CREDENTIALS_PASSPHRASE is used.
+var PromptPassphrase func(prompt string) (string, error)
+
+const (
+	pbkdf2Iterations = 600000
+	credentialsFile  = "credentials.enc"
+)
+
+// encryptedFile is the on-disk format of the credentials file.
+type encryptedFile struct {
+	Salt  []byte `json:"salt"`
+	Nonce []byte `json:"nonce"`
+	Data  []byte `json:"data"`
+}
+
+// fileStore keeps API keys in an AES-GCM encrypted file next to
+// config.yaml, with a key derived from a passphrase via PBKDF2.
+type fileStore struct{}
+
+func (fileStore) Get(alias string) (string, error) {
+	secrets, _, err := readCredentials()
+	if err != nil {
+		return "", err
+	}
+	key, ok := secrets[alias]
+	if !ok {
+		return "", fmt.Errorf("暗号化ファイルにスペース '%s' の API キーがありません", alias)
+	}
+	return key, nil
+}
+
+func (fileStore) Set(alias, secret string) error {
+	secrets, passphrase, err := readCredentials()
+	if err != nil {
+		return err
+	}
+	secrets[alias] = secret
+	return writeCredentials(secrets, passphrase)
+}
+
+func (fileStore) Delete(alias string) error {
+	secrets, passphrase, err := readCredentials()
+	if err != nil {
+		return err
+	}
+	delete(secrets, alias)
+	return writeCredentials(secrets, passphrase)
+}
+
+func credentialsPath() (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, credentialsFile), nil
+}
+
+func passphrase() (string, error) {
+	if p := os.Getenv(EnvPassphrase); p != "" {
+		return p, nil
+	}
+	if PromptPassphrase == nil {
+		return "", fmt.Errorf("%s を設定してください", EnvPassphrase)
+	}
+	p, err := PromptPassphrase("認証情報ファイルのパスフレーズ: ")
+	if err != nil {
+		return "", err
+	}
+	if p == "" {
+		return "", fmt.Errorf("パスフレーズが入力されませんでした")
+	}
+	return p, nil
+}
+
+// readCredentials decrypts the credentials file. A missing file yields an
+// empty map. The passphrase is returned so callers can write back.
+func readCredentials() (map[string]string, string, error) {
+	p, err := credentialsPath()
+	if err != nil {
+		return nil, "", err
+	}
+	pass, err := passphrase()
+	if err != nil {
+		return nil, "", err
+	}
+
+	data, err := os.ReadFile(p)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return map[string]string{}, pass, nil
+		}
+		return nil, "", fmt.Errorf("認証情報ファイルの読み込みに失敗しました: %w", err)
+	}
+
+	var ef encryptedFile
+	if err := json.Unmarshal(data, &ef); err != nil {
+		return nil, "", fmt.Errorf("認証情報ファイルの解析に失敗しました: %w", err)
+	}
+	gcm, err := newGCM(pass, ef.Salt)
+	if err != nil {
+		return nil, "", err
+	}
+	plain, err := gcm.Open(nil, ef.Nonce, ef.Data, nil)
+	if err != nil {
+		return nil, "", fmt.Errorf("認証情報ファイルの復号に失敗しました（パスフレーズが違う可能性があります）")
+	}
+
+	secrets := map[string]string{}
+	if err := json.Unmarshal(plain, &secrets); err != nil {
+		return nil, "", fmt.Errorf("認証情報ファイルの解析に失敗しました: %w", err)
+	}
+	return secrets, pass, nil
+}
+
+func writeCredentials(secrets map[string]string, pass string) error {
+	plain, err := json.Marshal(secrets)
+	if err != nil {
+		return fmt.Errorf("認証情報のシリアライズに失敗しました: %w", err)
+	}
+
+	ef := encryptedFile{Salt: make([]byte, 16)}
+	if _, err := rand.Read(ef.Salt); err != nil {
+		return fmt.Errorf("乱数の生成に失敗しました: %w", err)
+	}
+	gcm, err := newGCM(pass, ef.Salt)
+	if err != nil {
+		return err
+	}
+	ef.Nonce = make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(ef.Nonce); err != nil {
+		return fmt.Errorf("乱数の生成に失敗しました: %w", err)
+	}
+	ef.Data = gcm.Seal(nil, ef.Nonce, plain, nil)
+
+	data, err := json.Marshal(ef)
+	if err != nil {
+		return fmt.Errorf("認証情報のシリアライズに失敗しました: %w", err)
+	}
+
+	dir, err := Dir()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("設定ディレクトリの作成に失敗しました: %w", err)
+	}
+	p, err := credentialsPath()
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(p, data, 0600)
+}
+
+func newGCM(pass string, salt []byte) (cipher.AEAD, error) {
+	key, err := pbkdf2.Key(sha256.New, pass, salt, pbkdf2Iterations, 32)
+	if err != nil {
+		return nil, fmt.Errorf("鍵の導出に失敗しました: %w", err)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("暗号化の初期化に失敗しました: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
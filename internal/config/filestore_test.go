@@ -0,0 +1,87 @@
+package config
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFileStoreRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv(EnvConfigDir, dir)
+	t.Setenv(EnvPassphrase, "correct horse")
+
+	s := fileStore{}
+	if err := s.Set("main", "main-key"); err != nil {
+		t.Fatalf("Set(main) error = %v", err)
+	}
+	if err := s.Set("other", "other-key"); err != nil {
+		t.Fatalf("Set(other) error = %v", err)
+	}
+	if got, err := s.Get("main"); err != nil || got != "main-key" {
+		t.Errorf("Get(main) = %q, %v, want main-key", got, err)
+	}
+
+	p := filepath.Join(dir, credentialsFile)
+	info, err := os.Stat(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm != 0600 {
+		t.Errorf("credentials file mode = %o, want 600", perm)
+	}
+	data, err := os.ReadFile(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(data, []byte("main-key")) {
+		t.Error("credentials file contains the API key in plain text")
+	}
+
+	if err := s.Delete("main"); err != nil {
+		t.Fatalf("Delete(main) error = %v", err)
+	}
+	if _, err := s.Get("main"); err == nil {
+		t.Error("Get(main) after Delete succeeded, want error")
+	}
+	if got, err := s.Get("other"); err != nil || got != "other-key" {
+		t.Errorf("Get(other) after Delete(main) = %q, %v, want other-key", got, err)
+	}
+}
+
+func TestFileStoreWrongPassphrase(t *testing.T) {
+	t.Setenv(EnvConfigDir, t.TempDir())
+	t.Setenv(EnvPassphrase, "correct horse")
+	if err := (fileStore{}).Set("main", "main-key"); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv(EnvPassphrase, "wrong")
+	_, err := fileStore{}.Get("main")
+	if err == nil || !strings.Contains(err.Error(), "パスフレーズが違う") {
+		t.Errorf("Get() with a wrong passphrase error = %v, want a decryption error", err)
+	}
+	// A failed read must not overwrite the file with the wrong passphrase.
+	if err := (fileStore{}).Set("other", "other-key"); err == nil {
+		t.Error("Set() with a wrong passphrase succeeded, want error")
+	}
+	t.Setenv(EnvPassphrase, "correct horse")
+	if got, err := (fileStore{}).Get("main"); err != nil || got != "main-key" {
+		t.Errorf("Get() with the right passphrase = %q, %v, want main-key", got, err)
+	}
+}
+
+func TestFileStoreNoPassphrase(t *testing.T) {
+	t.Setenv(EnvConfigDir, t.TempDir())
+	t.Setenv(EnvPassphrase, "")
+	prompt := PromptPassphrase
+	PromptPassphrase = nil
+	t.Cleanup(func() { PromptPassphrase = prompt })
+
+	_, err := fileStore{}.Get("main")
+	if err == nil || !strings.Contains(err.Error(), EnvPassphrase) {
+		t.Errorf("Get() without a passphrase error = %v, want a hint to set %s", err, EnvPassphrase)
+	}
+}
@@ -0,0 +1,67 @@
+package config
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// helperStore delegates to an external credential helper, in the style of
+// git's credential helpers. The helper is invoked as "<command> <action>"
+// where action is get, store or erase, and receives key=value lines on
+// stdin:
+//
+//	space=<alias>
+//	secret=<api key>   (store only)
+//
+// For get, the helper prints "secret=<api key>" on stdout. A command
+// without spaces or slashes, such as "pass", runs bl-credential-pass.
+type helperStore struct {
+	command string
+}
+
+func (h helperStore) Get(alias string) (string, error) {
+	out, err := h.run("get", "space="+alias+"\n")
+	if err != nil {
+		return "", err
+	}
+	scanner := bufio.NewScanner(strings.NewReader(out))
+	for scanner.Scan() {
+		if v, ok := strings.CutPrefix(scanner.Text(), "secret="); ok {
+			return v, nil
+		}
+	}
+	return "", fmt.Errorf("credential helper が secret を返しませんでした")
+}
+
+func (h helperStore) Set(alias, secret string) error {
+	_, err := h.run("store", "space="+alias+"\nsecret="+secret+"\n")
+	return err
+}
+
+func (h helperStore) Delete(alias string) error {
+	_, err := h.run("erase", "space="+alias+"\n")
+	return err
+}
+
+func (h helperStore) run(action, input string) (string, error) {
+	command := h.command
+	if !strings.ContainsAny(command, " /\\") {
+		command = "bl-credential-" + command
+	}
+
+	cmd := exec.Command("sh", "-c", command+" "+action)
+	cmd.Stdin = strings.NewReader(input)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("credential helper (%s): %s", action, msg)
+		}
+		return "", fmt.Errorf("credential helper (%s): %w", action, err)
+	}
+	return string(out), nil
+}
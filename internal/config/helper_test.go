@@ -0,0 +1,93 @@
+package config
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// fakeHelper is a credential helper that keeps each secret in a file named
+// after the space and logs every action.
+const fakeHelper = `#!/bin/sh
+dir=$(dirname "$0")
+while IFS='=' read -r k v; do
+	case $k in
+	space) space=$v ;;
+	secret) secret=$v ;;
+	esac
+done
+echo "$1 $space" >> "$dir/log"
+case $1 in
+get)
+	[ -f "$dir/$space.secret" ] || { echo "no secret for $space" >&2; exit 1; }
+	echo "user=ignored"
+	printf 'secret=%s\n' "$(cat "$dir/$space.secret")"
+	;;
+store) printf '%s' "$secret" > "$dir/$space.secret" ;;
+erase) rm -f "$dir/$space.secret" ;;
+esac
+`
+
+// writeFakeHelper installs fakeHelper as bl-credential-fake in a new
+// directory and returns its path.
+func writeFakeHelper(t *testing.T) string {
+	t.Helper()
+	p := filepath.Join(t.TempDir(), "bl-credential-fake")
+	if err := os.WriteFile(p, []byte(fakeHelper), 0755); err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+func TestHelperStore(t *testing.T) {
+	p := writeFakeHelper(t)
+	dir := filepath.Dir(p)
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	for _, command := range []string{p, "fake"} {
+		t.Run(command, func(t *testing.T) {
+			store, err := NewSecretStore(StoreHelper, command)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := store.Set("main", "key=with=equals"); err != nil {
+				t.Fatalf("Set() error = %v", err)
+			}
+			if got, err := store.Get("main"); err != nil || got != "key=with=equals" {
+				t.Errorf("Get() = %q, %v, want key=with=equals", got, err)
+			}
+			if err := store.Delete("main"); err != nil {
+				t.Fatalf("Delete() error = %v", err)
+			}
+			_, err = store.Get("main")
+			if err == nil || !strings.Contains(err.Error(), "no secret for main") {
+				t.Errorf("Get() after Delete error = %v, want the helper's stderr", err)
+			}
+		})
+	}
+
+	log, err := os.ReadFile(filepath.Join(dir, "log"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := strings.Repeat("store main\nget main\nerase main\nget main\n", 2)
+	if string(log) != want {
+		t.Errorf("helper calls = %q, want %q", log, want)
+	}
+}
+
+func TestHelperStoreNoSecret(t *testing.T) {
+	command, err := exec.LookPath("true")
+	if err != nil {
+		t.Skip(err)
+	}
+	store := helperStore{command: command}
+	if _, err := store.Get("main"); err == nil || !strings.Contains(err.Error(), "secret を返しませんでした") {
+		t.Errorf("Get() from a helper printing nothing error = %v, want a missing secret error", err)
+	}
+	if _, err := NewSecretStore(StoreHelper, " "); err == nil {
+		t.Error("NewSecretStore() without a helper command succeeded, want error")
+	}
+}
@@ -0,0 +1,98 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// keyringService is the service name API keys are filed under.
+const keyringService = "bl-cli"
+
+// keyringStore keeps API keys in the OS keyring: the login keychain on
+// macOS (security) and the Secret Service on Linux (secret-tool).
+type keyringStore struct{}
+
+func (keyringStore) Get(alias string) (string, error) {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("security", "find-generic-password", "-s", keyringService, "-a", alias, "-w")
+	case "linux":
+		cmd = exec.Command("secret-tool", "lookup", "service", keyringService, "account", alias)
+	default:
+		return "", unsupportedKeyring()
+	}
+	out, err := runKeyring(cmd, "")
+	if err != nil {
+		return "", err
+	}
+	key := strings.TrimSpace(out)
+	if key == "" {
+		return "", fmt.Errorf("キーリングにスペース '%s' の API キーがありません", alias)
+	}
+	return key, nil
+}
+
+func (keyringStore) Set(alias, secret string) error {
+	var cmd *exec.Cmd
+	stdin := ""
+	switch runtime.GOOS {
+	case "darwin":
+		// security reads the command from stdin with -i, so the secret
+		// never appears in the process list.
+		cmd = exec.Command("security", "-i")
+		stdin = strings.Join([]string{
+			"add-generic-password", "-U",
+			"-s", securityQuote(keyringService),
+			"-a", securityQuote(alias),
+			"-w", securityQuote(secret),
+		}, " ") + "\n"
+	case "linux":
+		cmd = exec.Command("secret-tool", "store", "--label", keyringService+" ("+alias+")", "service", keyringService, "account", alias)
+		stdin = secret
+	default:
+		return unsupportedKeyring()
+	}
+	_, err := runKeyring(cmd, stdin)
+	return err
+}
+
+func (keyringStore) Delete(alias string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("security", "delete-generic-password", "-s", keyringService, "-a", alias)
+	case "linux":
+		cmd = exec.Command("secret-tool", "clear", "service", keyringService, "account", alias)
+	default:
+		return unsupportedKeyring()
+	}
+	_, err := runKeyring(cmd, "")
+	return err
+}
+
+func runKeyring(cmd *exec.Cmd, stdin string) (string, error) {
+	cmd.Stdin = strings.NewReader(stdin)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("%s: %s", cmd.Args[0], msg)
+		}
+		return "", fmt.Errorf("%s: %w", cmd.Args[0], err)
+	}
+	return string(out), nil
+}
+
+// securityQuote quotes s as a single argument for security -i.
+func securityQuote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+func unsupportedKeyring() error {
+	return fmt.Errorf("キーリングに対応していないOS: %s", runtime.GOOS)
+}
@@ -0,0 +1,111 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"go.yaml.in/yaml/v3"
+)
+
+// LocalConfigFile is the name of the per-repository config file.
+const LocalConfigFile = ".bl.yaml"
+
+// LocalConfig holds per-repository settings read from .bl.yaml. Its
+// values take precedence over the space settings in the global config.
+type LocalConfig struct {
+	Space          string `yaml:"space,omitempty"`
+	Project        string `yaml:"project,omitempty"`
+	BranchTemplate string `yaml:"branch_template,omitempty"`
+	IssueType      string `yaml:"issue_type,omitempty"`
+	Priority       string `yaml:"priority,omitempty"`
+
+	IssueKey *IssueKeyConfig `yaml:"issue_key,omitempty"`
+	Views    map[string]View `yaml:"views,omitempty"`
+}
+
+// FindLocalConfig looks for .bl.yaml in the current directory and its
+// parents, stopping at the repository root (the directory containing
+// .git). It returns the path of the file, or "" if none was found.
+func FindLocalConfig() (string, error) {
+	dir, err := os.Getwd()
+	if err != nil {
+		return "", fmt.Errorf("カレントディレクトリの取得に失敗しました: %w", err)
+	}
+	for {
+		p := filepath.Join(dir, LocalConfigFile)
+		if _, err := os.Stat(p); err == nil {
+			return p, nil
+		}
+		if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+			return "", nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", nil
+		}
+		dir = parent
+	}
+}
+
+// LocalConfigPath returns where .bl.yaml should be written: the existing
+// file if there is one, otherwise the repository root, or the current
+// directory outside a repository.
+func LocalConfigPath() (string, error) {
+	if p, err := FindLocalConfig(); err != nil || p != "" {
+		return p, err
+	}
+	cwd, err := os.Getwd()
+	if err != nil {
+		return "", fmt.Errorf("カレントディレクトリの取得に失敗しました: %w", err)
+	}
+	for dir := cwd; ; {
+		if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+			return filepath.Join(dir, LocalConfigFile), nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return filepath.Join(cwd, LocalConfigFile), nil
+		}
+		dir = parent
+	}
+}
+
+// LoadLocal reads the .bl.yaml that applies to the current directory.
+// It returns nil if there is none.
+func LoadLocal() (*LocalConfig, error) {
+	p, err := FindLocalConfig()
+	if err != nil || p == "" {
+		return nil, err
+	}
+	return ReadLocal(p)
+}
+
+// ReadLocal reads the local config file at p. A missing file yields an
+// empty LocalConfig.
+func ReadLocal(p string) (*LocalConfig, error) {
+	data, err := os.ReadFile(p)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &LocalConfig{}, nil
+		}
+		return nil, fmt.Errorf("%s の読み込みに失敗しました: %w", p, err)
+	}
+	var local LocalConfig
+	if err := yaml.Unmarshal(data, &local); err != nil {
+		return nil, fmt.Errorf("%s の解析に失敗しました: %w", p, err)
+	}
+	return &local, nil
+}
+
+// SaveLocal writes local to the file at p.
+func SaveLocal(p string, local *LocalConfig) error {
+	data, err := yaml.Marshal(local)
+	if err != nil {
+		return fmt.Errorf("設定のシリアライズに失敗しました: %w", err)
+	}
+	if err := os.WriteFile(p, data, 0644); err != nil {
+		return fmt.Errorf("%s の書き込みに失敗しました: %w", p, err)
+	}
+	return nil
+}
@@ -0,0 +1,169 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Credential store backends.
+const (
+	StorePlain   = "plain"
+	StoreKeyring = "keyring"
+	StoreHelper  = "helper"
+	StoreFile    = "file"
+)
+
+// StoreNames lists the supported credential store backends.
+var StoreNames = []string{StorePlain, StoreKeyring, StoreHelper, StoreFile}
+
+// SecretStore persists credentials outside the config file, keyed by
+// space alias.
+type SecretStore interface {
+	Get(alias string) (string, error)
+	Set(alias, secret string) error
+	Delete(alias string) error
+}
+
+// NewSecretStore returns the store for backend. helper is the credential
+// helper command and is only used by StoreHelper.
+func NewSecretStore(backend, helper string) (SecretStore, error) {
+	switch backend {
+	case StoreKeyring:
+		return keyringStore{}, nil
+	case StoreHelper:
+		if strings.TrimSpace(helper) == "" {
+			return nil, fmt.Errorf("credential helper のコマンドが指定されていません")
+		}
+		return helperStore{command: helper}, nil
+	case StoreFile:
+		return fileStore{}, nil
+	default:
+		return nil, fmt.Errorf("不明な認証情報の保存先です: %s（%s）", backend, strings.Join(StoreNames, ", "))
+	}
+}
+
+// usesSecretStore reports whether the credentials are kept outside
+// config.yaml.
+func (s *SpaceConfig) usesSecretStore() bool {
+	return s.CredentialStore != "" && s.CredentialStore != StorePlain
+}
+
+// LoadCredentials fills APIKey or OAuth from the secret store when they
+// are not kept in the config file. It does nothing if the credentials are
+// already present, e.g. from BL_API_KEY. For OAuth spaces the stored
+// secret is the JSON-encoded OAuthCredentials.
+func (s *SpaceConfig) LoadCredentials(alias string) error {
+	if s.Authenticated() || !s.usesSecretStore() {
+		return nil
+	}
+	store, err := NewSecretStore(s.CredentialStore, s.CredentialHelper)
+	if err != nil {
+		return err
+	}
+	secret, err := store.Get(alias)
+	if err != nil {
+		return fmt.Errorf("認証情報の取得に失敗しました（%s）: %w", s.CredentialStore, err)
+	}
+
+	if s.AuthType == AuthOAuth {
+		var creds OAuthCredentials
+		if err := json.Unmarshal([]byte(secret), &creds); err != nil {
+			return fmt.Errorf("OAuth 認証情報の解析に失敗しました: %w", err)
+		}
+		s.OAuth = &creds
+		return nil
+	}
+	s.APIKey = secret
+	return nil
+}
+
+// StoreAPIKey saves key in backend and records the backend on s. With
+// StorePlain the key is kept in the config file as before.
+func (s *SpaceConfig) StoreAPIKey(alias, backend, helper, key string) error {
+	s.AuthType = AuthAPIKey
+	s.OAuth = nil
+	if backend == "" || backend == StorePlain {
+		s.APIKey = key
+		s.CredentialStore = ""
+		s.CredentialHelper = ""
+		return nil
+	}
+	if err := storeSecret(alias, backend, helper, key); err != nil {
+		return err
+	}
+	s.APIKey = ""
+	s.CredentialStore = backend
+	s.CredentialHelper = helper
+	return nil
+}
+
+// StoreOAuth saves OAuth credentials in backend and records the backend
+// on s. With StorePlain they are kept in the config file.
+func (s *SpaceConfig) StoreOAuth(alias, backend, helper string, creds *OAuthCredentials) error {
+	s.AuthType = AuthOAuth
+	s.APIKey = ""
+	if backend == "" || backend == StorePlain {
+		s.OAuth = creds
+		s.CredentialStore = ""
+		s.CredentialHelper = ""
+		return nil
+	}
+	data, err := json.Marshal(creds)
+	if err != nil {
+		return fmt.Errorf("OAuth 認証情報のシリアライズに失敗しました: %w", err)
+	}
+	if err := storeSecret(alias, backend, helper, string(data)); err != nil {
+		return err
+	}
+	s.OAuth = nil
+	s.CredentialStore = backend
+	s.CredentialHelper = helper
+	return nil
+}
+
+func storeSecret(alias, backend, helper, secret string) error {
+	store, err := NewSecretStore(backend, helper)
+	if err != nil {
+		return err
+	}
+	if err := store.Set(alias, secret); err != nil {
+		return fmt.Errorf("認証情報の保存に失敗しました（%s）: %w", backend, err)
+	}
+	return nil
+}
+
+// DeleteCredentials removes the credentials from the secret store, if
+// one is used.
+func (s *SpaceConfig) DeleteCredentials(alias string) error {
+	if !s.usesSecretStore() {
+		return nil
+	}
+	store, err := NewSecretStore(s.CredentialStore, s.CredentialHelper)
+	if err != nil {
+		return err
+	}
+	if err := store.Delete(alias); err != nil {
+		return fmt.Errorf("認証情報の削除に失敗しました（%s）: %w", s.CredentialStore, err)
+	}
+	return nil
+}
+
+// UpdateOAuthToken persists a refreshed OAuth token for alias in whichever
+// store the space uses. Only the global config is read, so settings from
+// .bl.yaml are never written back to config.yaml.
+func UpdateOAuthToken(alias string, creds *OAuthCredentials) error {
+	cfg, err := LoadGlobal()
+	if err != nil {
+		return err
+	}
+	space, ok := cfg.Spaces[alias]
+	if !ok || space.AuthType != AuthOAuth {
+		return nil
+	}
+	if err := space.StoreOAuth(alias, space.CredentialStore, space.CredentialHelper, creds); err != nil {
+		return err
+	}
+	cfg.Spaces[alias] = space
+	return Save(cfg)
+}
@@ -0,0 +1,53 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// View is a saved set of bl issue list filters, replayed with
+// bl issue list @NAME or bl view run NAME. Empty fields fall back to the
+// bl issue list defaults, so a view without a project follows the
+// default project of whoever runs it.
+type View struct {
+	Project string `yaml:"project,omitempty" json:"project,omitempty"`
+	// Query is a search query, e.g. "status:処理中 assignee:@me".
+	Query string `yaml:"query,omitempty" json:"query,omitempty"`
+	// Fields are custom field filters as given to --field.
+	Fields  []string `yaml:"fields,omitempty" json:"fields,omitempty"`
+	Sort    string   `yaml:"sort,omitempty" json:"sort,omitempty"`
+	Order   string   `yaml:"order,omitempty" json:"order,omitempty"`
+	Columns []string `yaml:"columns,omitempty" json:"columns,omitempty"`
+}
+
+var viewNamePattern = regexp.MustCompile(`^[\p{L}\p{N}_-]+$`)
+
+// ValidateViewName reports whether name can be used as a view name.
+func ValidateViewName(name string) error {
+	if !viewNamePattern.MatchString(name) {
+		return fmt.Errorf("ビュー名には英数字・日本語・'-'・'_' のみ使用できます: %s", name)
+	}
+	return nil
+}
+
+// View returns the named view. Views in .bl.yaml take precedence over
+// those in the global config.
+func (c *Config) View(name string) (View, bool) {
+	if v, ok := c.Local().Views[name]; ok {
+		return v, true
+	}
+	v, ok := c.Views[name]
+	return v, ok
+}
+
+// SaveView adds or replaces a view in the global config and saves it.
+func (c *Config) SaveView(name string, v View) error {
+	if err := ValidateViewName(name); err != nil {
+		return err
+	}
+	if c.Views == nil {
+		c.Views = map[string]View{}
+	}
+	c.Views[name] = v
+	return Save(c)
+}
@@ -1,12 +1,63 @@
 package git
 
 import (
+	"bytes"
+	"errors"
+	"fmt"
 	"os/exec"
+	"path/filepath"
 	"regexp"
 	"strings"
+	"text/template"
 )
 
-var issueKeyPattern = regexp.MustCompile(`[A-Z][A-Z0-9_]+-\d+`)
+// DefaultBranchTemplate is the branch name template used when neither a
+// flag nor .bl.yaml provides one.
+const DefaultBranchTemplate = "feature/{{.IssueKey}}-{{slug .Summary}}"
+
+// BranchData is the data available to branch name templates.
+type BranchData struct {
+	IssueKey   string
+	ProjectKey string
+	Summary    string
+	IssueType  string
+	ID         int
+}
+
+var branchFuncs = template.FuncMap{
+	"slug":  Slug,
+	"lower": strings.ToLower,
+	"upper": strings.ToUpper,
+}
+
+var (
+	repeatedSeparators = regexp.MustCompile(`([-_/.])[-_/.]+`)
+	danglingSeparators = regexp.MustCompile(`[-_.]+(/|$)`)
+)
+
+// BranchName renders tmpl with data. Separators left over by empty
+// values, e.g. when the summary has no transliterable characters, are
+// removed, and the result is checked with git check-ref-format.
+func BranchName(tmpl string, data BranchData) (string, error) {
+	t, err := template.New("branch").Funcs(branchFuncs).Parse(tmpl)
+	if err != nil {
+		return "", fmt.Errorf("ブランチ名テンプレートの解析に失敗しました: %w", err)
+	}
+	var b strings.Builder
+	if err := t.Execute(&b, data); err != nil {
+		return "", fmt.Errorf("ブランチ名テンプレートの実行に失敗しました: %w", err)
+	}
+
+	name := strings.Join(strings.Fields(b.String()), "-")
+	name = repeatedSeparators.ReplaceAllString(name, "$1")
+	name = danglingSeparators.ReplaceAllString(name, "$1")
+	name = strings.Trim(name, "/")
+
+	if _, err := run("check-ref-format", "--branch", name); err != nil {
+		return "", fmt.Errorf("ブランチ名 '%s' は使用できません: %w", name, err)
+	}
+	return name, nil
+}
 
 // GetCurrentBranch returns the current git branch name.
 func GetCurrentBranch() (string, error) {
@@ -17,9 +68,78 @@ func GetCurrentBranch() (string, error) {
 	return strings.TrimSpace(string(out)), nil
 }
 
-// ExtractIssueKey extracts a Backlog issue key from a branch name.
-// Returns an empty string if no match is found.
-func ExtractIssueKey(branch string) string {
-	match := issueKeyPattern.FindString(branch)
-	return match
+// BranchExists reports whether a local branch named name exists.
+func BranchExists(name string) bool {
+	_, err := run("show-ref", "--verify", "--quiet", "refs/heads/"+name)
+	return err == nil
+}
+
+// CreateBranch creates a branch named name from base (HEAD if empty)
+// and checks it out.
+func CreateBranch(name, base string) error {
+	args := []string{"checkout", "-b", name}
+	if base != "" {
+		args = append(args, base)
+	}
+	_, err := run(args...)
+	return err
+}
+
+// Checkout switches to an existing branch.
+func Checkout(name string) error {
+	_, err := run("checkout", name)
+	return err
+}
+
+// UpstreamBranch returns the name of the branch the current branch
+// tracks, e.g. "origin/feature/PROJ-123".
+func UpstreamBranch() (string, error) {
+	return run("rev-parse", "--abbrev-ref", "--symbolic-full-name", "@{upstream}")
+}
+
+// LatestCommitMessage returns the full message of the HEAD commit.
+func LatestCommitMessage() (string, error) {
+	return run("log", "-1", "--format=%B")
+}
+
+// CommitMessages returns the full messages of the commits in revRange,
+// e.g. "v1.0..v1.1", newest first.
+func CommitMessages(revRange string) ([]string, error) {
+	out, err := run("log", "--format=%B%x00", revRange, "--")
+	if err != nil {
+		return nil, err
+	}
+	var msgs []string
+	for _, msg := range strings.Split(out, "\x00") {
+		if msg = strings.TrimSpace(msg); msg != "" {
+			msgs = append(msgs, msg)
+		}
+	}
+	return msgs, nil
+}
+
+// HooksDir returns the absolute path of the directory git runs hooks
+// from, honoring core.hooksPath.
+func HooksDir() (string, error) {
+	dir, err := run("rev-parse", "--git-path", "hooks")
+	if err != nil {
+		return "", err
+	}
+	return filepath.Abs(dir)
+}
+
+// run executes git with args and returns its trimmed stdout. On failure
+// the error carries git's stderr.
+func run(args ...string) (string, error) {
+	var stderr bytes.Buffer
+	cmd := exec.Command("git", args...)
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", errors.New(msg)
+		}
+		return "", err
+	}
+	return strings.TrimSpace(string(out)), nil
 }
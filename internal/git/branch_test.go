@@ -0,0 +1,50 @@
+package git
+
+import (
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+func TestBranchName(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	data := BranchData{IssueKey: "PROJ-12", ProjectKey: "PROJ", Summary: "ログイン画面のバグ", IssueType: "バグ", ID: 34}
+	tests := []struct {
+		name    string
+		tmpl    string
+		data    BranchData
+		want    string
+		wantErr string
+	}{
+		{"default", DefaultBranchTemplate, data, "feature/PROJ-12-roguin-bagu", ""},
+		{"funcs", "{{lower .ProjectKey}}/{{.ID}}-{{upper (slug .Summary)}}", data, "proj/34-ROGUIN-BAGU", ""},
+		{"empty slug", DefaultBranchTemplate, BranchData{IssueKey: "PROJ-1", Summary: "不具合修正"}, "feature/PROJ-1", ""},
+		{"empty directory", "{{slug .Summary}}/{{.IssueKey}}", BranchData{IssueKey: "PROJ-1", Summary: "修正"}, "PROJ-1", ""},
+		{"whitespace", "fix {{.IssueKey}}", data, "fix-PROJ-12", ""},
+		{"repeated separators", "fix--{{.IssueKey}}__x", data, "fix-PROJ-12_x", ""},
+		{"bad template", "{{.IssueKey", data, "", "ブランチ名テンプレートの解析に失敗しました"},
+		{"unknown field", "{{.Title}}", data, "", "ブランチ名テンプレートの実行に失敗しました"},
+		{"invalid ref", "{{.IssueKey}}~1", data, "", "ブランチ名 'PROJ-12~1' は使用できません"},
+		{"empty name", "{{slug .Summary}}", BranchData{Summary: "修正"}, "", "は使用できません"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := BranchName(tt.tmpl, tt.data)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("BranchName(%q) = %q, %v, want error %q", tt.tmpl, got, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("BranchName(%q) error = %v", tt.tmpl, err)
+			}
+			if got != tt.want {
+				t.Errorf("BranchName(%q) = %q, want %q", tt.tmpl, got, tt.want)
+			}
+		})
+	}
+}
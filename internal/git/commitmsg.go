@@ -0,0 +1,50 @@
+package git
+
+import "strings"
+
+// Ways of adding an issue key to a commit message.
+const (
+	KeyModePrefix = "prefix"
+	KeyModeAppend = "append"
+)
+
+// scissorsLine marks the start of the diff shown by git commit --verbose;
+// everything below it is discarded by git.
+const scissorsLine = "# ------------------------ >8 ------------------------"
+
+// MessageContent returns the lines of a commit message that git keeps,
+// i.e. without comment lines and the --verbose diff.
+func MessageContent(msg string) string {
+	var lines []string
+	for _, line := range strings.Split(msg, "\n") {
+		if line == scissorsLine {
+			break
+		}
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+// AddIssueKey adds key to the subject line of msg, before it in prefix
+// mode and after it in append mode. Comment lines are left untouched.
+func AddIssueKey(msg, key, mode string) string {
+	lines := strings.Split(msg, "\n")
+	for i, line := range lines {
+		if line == scissorsLine {
+			break
+		}
+		if strings.HasPrefix(line, "#") || strings.TrimSpace(line) == "" {
+			continue
+		}
+		if mode == KeyModeAppend {
+			lines[i] = strings.TrimRight(line, " ") + " " + key
+		} else {
+			lines[i] = key + " " + line
+		}
+		return strings.Join(lines, "\n")
+	}
+	return msg
+}
@@ -0,0 +1,55 @@
+package git
+
+import "testing"
+
+const verboseDiff = scissorsLine + "\n# Do not modify or remove the line above.\ndiff --git a/x b/x\n"
+
+func TestMessageContent(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"empty", "", ""},
+		{"template only", "\n# Please enter the commit message.\n#\n", ""},
+		{"subject and body", "Fix login\n\nDetails\n# comment\n", "Fix login\n\nDetails"},
+		{"verbose diff", "Fix login\n" + verboseDiff, "Fix login"},
+		{"diff only", "\n" + verboseDiff, ""},
+		{"hash after text is kept", "Fix #12\n", "Fix #12"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MessageContent(tt.in); got != tt.want {
+				t.Errorf("MessageContent(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAddIssueKey(t *testing.T) {
+	tests := []struct {
+		name string
+		msg  string
+		mode string
+		want string
+	}{
+		{"prefix", "Fix login\n\nDetails\n", KeyModePrefix, "PROJ-1 Fix login\n\nDetails\n"},
+		{"default mode is prefix", "Fix login\n", "", "PROJ-1 Fix login\n"},
+		{"append", "Fix login  \n", KeyModeAppend, "Fix login PROJ-1\n"},
+		{
+			"skips comments and blank lines",
+			"# Please enter the commit message.\n\nFix login\n",
+			KeyModePrefix,
+			"# Please enter the commit message.\n\nPROJ-1 Fix login\n",
+		},
+		{"only comments", "\n# Please enter the commit message.\n", KeyModePrefix, "\n# Please enter the commit message.\n"},
+		{"stops at the verbose diff", "\n" + verboseDiff + "+added\n", KeyModePrefix, "\n" + verboseDiff + "+added\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := AddIssueKey(tt.msg, "PROJ-1", tt.mode); got != tt.want {
+				t.Errorf("AddIssueKey(%q, %q) = %q, want %q", tt.msg, tt.mode, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package git
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// DefaultIssueKeyPattern matches Backlog issue keys such as PROJ-123.
+const DefaultIssueKeyPattern = `[A-Z][A-Z0-9_]+-\d+`
+
+// KeyMatcher finds Backlog issue keys in branch names and commit messages.
+type KeyMatcher struct {
+	patterns []*regexp.Regexp
+	projects []string
+}
+
+// NewKeyMatcher compiles patterns into a KeyMatcher. With no patterns
+// DefaultIssueKeyPattern is used. If a pattern has a capture group, the
+// first group is taken as the key. ignoreCase makes every pattern
+// case-insensitive. When projects is not empty, only keys of those
+// projects are returned.
+func NewKeyMatcher(patterns []string, ignoreCase bool, projects []string) (*KeyMatcher, error) {
+	if len(patterns) == 0 {
+		patterns = []string{DefaultIssueKeyPattern}
+	}
+	m := &KeyMatcher{}
+	for _, p := range patterns {
+		if ignoreCase {
+			p = "(?i)" + p
+		}
+		re, err := regexp.Compile(p)
+		if err != nil {
+			return nil, fmt.Errorf("課題キーのパターン '%s' が不正です: %w", p, err)
+		}
+		m.patterns = append(m.patterns, re)
+	}
+	for _, p := range projects {
+		m.projects = append(m.projects, strings.ToUpper(p))
+	}
+	return m, nil
+}
+
+// FindAll returns the distinct issue keys in s, upper-cased, in order of
+// appearance.
+func (m *KeyMatcher) FindAll(s string) []string {
+	type match struct {
+		pos int
+		key string
+	}
+	var matches []match
+	for _, re := range m.patterns {
+		for _, loc := range re.FindAllStringSubmatchIndex(s, -1) {
+			start, end := loc[0], loc[1]
+			if len(loc) >= 4 && loc[2] >= 0 {
+				start, end = loc[2], loc[3]
+			}
+			matches = append(matches, match{start, strings.ToUpper(s[start:end])})
+		}
+	}
+	slices.SortStableFunc(matches, func(a, b match) int { return a.pos - b.pos })
+
+	var keys []string
+	for _, mt := range matches {
+		if slices.Contains(keys, mt.key) || !m.allowed(mt.key) {
+			continue
+		}
+		keys = append(keys, mt.key)
+	}
+	return keys
+}
+
+func (m *KeyMatcher) allowed(key string) bool {
+	if len(m.projects) == 0 {
+		return true
+	}
+	project, _, _ := strings.Cut(key, "-")
+	return slices.Contains(m.projects, project)
+}
+
+// KeySource tells where FindIssueKeys found the issue keys.
+type KeySource int
+
+const (
+	KeyFromBranch KeySource = iota
+	KeyFromUpstream
+	KeyFromCommit
+)
+
+// FindIssueKeys looks for issue keys in the current branch name, then the
+// upstream branch name, then the latest commit message, and returns the
+// keys from the first source that has any together with that source.
+func FindIssueKeys(m *KeyMatcher) ([]string, KeySource) {
+	sources := []struct {
+		get    func() (string, error)
+		source KeySource
+	}{
+		{GetCurrentBranch, KeyFromBranch},
+		{UpstreamBranch, KeyFromUpstream},
+		{LatestCommitMessage, KeyFromCommit},
+	}
+	for _, src := range sources {
+		s, err := src.get()
+		if err != nil {
+			continue
+		}
+		if keys := m.FindAll(s); len(keys) > 0 {
+			return keys, src.source
+		}
+	}
+	return nil, KeyFromBranch
+}
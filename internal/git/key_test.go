@@ -0,0 +1,108 @@
+package git
+
+import (
+	"os/exec"
+	"reflect"
+	"testing"
+)
+
+func TestKeyMatcherFindAll(t *testing.T) {
+	tests := []struct {
+		name       string
+		patterns   []string
+		ignoreCase bool
+		projects   []string
+		in         string
+		want       []string
+	}{
+		{"branch", nil, false, nil, "feature/PROJ-123-login", []string{"PROJ-123"}},
+		{"commit message", nil, false, nil, "[PROJ_2-5] fix PROJ-1 and PROJ_2-5", []string{"PROJ_2-5", "PROJ-1"}},
+		{"no key", nil, false, nil, "main", nil},
+		{"lower case ignored by default", nil, false, nil, "feature/proj-123", nil},
+		{"ignore case", nil, true, nil, "feature/proj-123", []string{"PROJ-123"}},
+		{"single letter project", nil, false, nil, "P-1", nil},
+		{"projects filter", nil, false, []string{"proj"}, "OTHER-1 PROJ-2", []string{"PROJ-2"}},
+		{
+			"capture group",
+			[]string{`issue/(\d+)`, `#([A-Z]+-\d+)`},
+			false, nil,
+			"issue/42 refs #PROJ-7",
+			[]string{"42", "PROJ-7"},
+		},
+		{
+			"order of appearance across patterns",
+			[]string{`B-\d+`, `A-\d+`},
+			false, nil,
+			"A-1 B-2 A-3",
+			[]string{"A-1", "B-2", "A-3"},
+		},
+		{
+			"duplicates across patterns",
+			[]string{DefaultIssueKeyPattern, `(?i)proj-\d+`},
+			false, nil,
+			"PROJ-1 proj-1",
+			[]string{"PROJ-1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := NewKeyMatcher(tt.patterns, tt.ignoreCase, tt.projects)
+			if err != nil {
+				t.Fatalf("NewKeyMatcher() error = %v", err)
+			}
+			if got := m.FindAll(tt.in); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FindAll(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewKeyMatcherInvalidPattern(t *testing.T) {
+	if _, err := NewKeyMatcher([]string{`PROJ-(\d+`}, false, nil); err == nil {
+		t.Error("NewKeyMatcher() succeeded with an invalid pattern")
+	}
+}
+
+func TestFindIssueKeys(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	t.Chdir(t.TempDir())
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("GIT_CONFIG_NOSYSTEM", "1")
+	for _, k := range []string{"GIT_AUTHOR_NAME", "GIT_COMMITTER_NAME"} {
+		t.Setenv(k, "test")
+	}
+	for _, k := range []string{"GIT_AUTHOR_EMAIL", "GIT_COMMITTER_EMAIL"} {
+		t.Setenv(k, "test@example.com")
+	}
+	git := func(args ...string) {
+		t.Helper()
+		if out, err := exec.Command("git", args...).CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	m, err := NewKeyMatcher(nil, false, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	check := func(want []string, wantSource KeySource) {
+		t.Helper()
+		keys, source := FindIssueKeys(m)
+		if !reflect.DeepEqual(keys, want) || source != wantSource {
+			t.Errorf("FindIssueKeys() = %q, %d, want %q, %d", keys, source, want, wantSource)
+		}
+	}
+
+	git("init", "-q", "-b", "main")
+	check(nil, KeyFromBranch)
+
+	git("commit", "-q", "--allow-empty", "-m", "PROJ-9 fix login")
+	check([]string{"PROJ-9"}, KeyFromCommit)
+
+	git("checkout", "-q", "-b", "feature/PROJ-3-login")
+	check([]string{"PROJ-3"}, KeyFromBranch)
+
+	git("checkout", "-q", "-b", "topic", "--track", "feature/PROJ-3-login")
+	check([]string{"PROJ-3"}, KeyFromUpstream)
+}
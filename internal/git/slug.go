@@ -0,0 +1,158 @@
+package git
+
+import (
+	"strings"
+	"unicode"
+)
+
+// maxSlugLength caps the length of a slug so branch names stay readable.
+const maxSlugLength = 40
+
+// Romanization of hiragana (Hepburn). Katakana is folded to hiragana
+// before lookup. Two-character entries are tried first.
+var kanaRomaji = map[string]string{
+	"あ": "a", "い": "i", "う": "u", "え": "e", "お": "o",
+	"か": "ka", "き": "ki", "く": "ku", "け": "ke", "こ": "ko",
+	"さ": "sa", "し": "shi", "す": "su", "せ": "se", "そ": "so",
+	"た": "ta", "ち": "chi", "つ": "tsu", "て": "te", "と": "to",
+	"な": "na", "に": "ni", "ぬ": "nu", "ね": "ne", "の": "no",
+	"は": "ha", "ひ": "hi", "ふ": "fu", "へ": "he", "ほ": "ho",
+	"ま": "ma", "み": "mi", "む": "mu", "め": "me", "も": "mo",
+	"や": "ya", "ゆ": "yu", "よ": "yo",
+	"ら": "ra", "り": "ri", "る": "ru", "れ": "re", "ろ": "ro",
+	"わ": "wa", "ゐ": "i", "ゑ": "e", "を": "o", "ん": "n",
+	"が": "ga", "ぎ": "gi", "ぐ": "gu", "げ": "ge", "ご": "go",
+	"ざ": "za", "じ": "ji", "ず": "zu", "ぜ": "ze", "ぞ": "zo",
+	"だ": "da", "ぢ": "ji", "づ": "zu", "で": "de", "ど": "do",
+	"ば": "ba", "び": "bi", "ぶ": "bu", "べ": "be", "ぼ": "bo",
+	"ぱ": "pa", "ぴ": "pi", "ぷ": "pu", "ぺ": "pe", "ぽ": "po",
+	"ゔ": "vu",
+	"ぁ": "a", "ぃ": "i", "ぅ": "u", "ぇ": "e", "ぉ": "o",
+	"ゃ": "ya", "ゅ": "yu", "ょ": "yo", "ゎ": "wa",
+	"きゃ": "kya", "きゅ": "kyu", "きょ": "kyo",
+	"しゃ": "sha", "しゅ": "shu", "しょ": "sho", "しぇ": "she",
+	"ちゃ": "cha", "ちゅ": "chu", "ちょ": "cho", "ちぇ": "che",
+	"にゃ": "nya", "にゅ": "nyu", "にょ": "nyo",
+	"ひゃ": "hya", "ひゅ": "hyu", "ひょ": "hyo",
+	"みゃ": "mya", "みゅ": "myu", "みょ": "myo",
+	"りゃ": "rya", "りゅ": "ryu", "りょ": "ryo",
+	"ぎゃ": "gya", "ぎゅ": "gyu", "ぎょ": "gyo",
+	"じゃ": "ja", "じゅ": "ju", "じょ": "jo", "じぇ": "je",
+	"びゃ": "bya", "びゅ": "byu", "びょ": "byo",
+	"ぴゃ": "pya", "ぴゅ": "pyu", "ぴょ": "pyo",
+	"ふぁ": "fa", "ふぃ": "fi", "ふぇ": "fe", "ふぉ": "fo",
+	"てぃ": "ti", "でぃ": "di", "とぅ": "tu", "どぅ": "du",
+	"うぃ": "wi", "うぇ": "we", "うぉ": "wo",
+	"ゔぁ": "va", "ゔぃ": "vi", "ゔぇ": "ve", "ゔぉ": "vo",
+}
+
+// Slug converts s into a lowercase, hyphen-separated string usable in a
+// branch name. Kana is romanized; characters that cannot be transliterated,
+// such as kanji, are dropped. When s contains kanji its hiragana is mostly
+// particles and okurigana, so only katakana words are kept. The result may
+// be empty.
+func Slug(s string) string {
+	hasKanji := strings.IndexFunc(s, func(r rune) bool { return unicode.Is(unicode.Han, r) }) >= 0
+
+	const (
+		classNone = iota
+		classASCII
+		classHiragana
+		classKatakana
+	)
+	classOf := func(r rune) int {
+		switch {
+		case r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)):
+			return classASCII
+		case unicode.Is(unicode.Hiragana, r):
+			if hasKanji {
+				return classNone
+			}
+			return classHiragana
+		case unicode.Is(unicode.Katakana, r), r == 'ー':
+			return classKatakana
+		}
+		return classNone
+	}
+
+	runes := []rune(s)
+	classes := make([]int, len(runes))
+	for i, r := range runes {
+		r = foldWidth(r)
+		classes[i] = classOf(r)
+		runes[i] = foldKana(r)
+	}
+
+	var words []string
+	var word strings.Builder
+	flush := func() {
+		if word.Len() > 0 {
+			words = append(words, word.String())
+			word.Reset()
+		}
+	}
+
+	doubleNext := false
+	for i := 0; i < len(runes); i++ {
+		r, class := runes[i], classes[i]
+		if i > 0 && class != classes[i-1] {
+			flush()
+		}
+
+		switch {
+		case class == classNone:
+			doubleNext = false
+			continue
+		case class == classASCII:
+			word.WriteRune(unicode.ToLower(r))
+			continue
+		case r == 'っ':
+			doubleNext = true
+			continue
+		case r == 'ー':
+			continue
+		}
+
+		romaji := ""
+		if i+1 < len(runes) && classes[i+1] == class {
+			if v, ok := kanaRomaji[string(runes[i:i+2])]; ok {
+				romaji = v
+				i++
+			}
+		}
+		if romaji == "" {
+			romaji = kanaRomaji[string(r)]
+		}
+		if doubleNext && romaji != "" {
+			romaji = romaji[:1] + romaji
+		}
+		doubleNext = false
+		word.WriteString(romaji)
+	}
+	flush()
+
+	slug := strings.Join(words, "-")
+	if len(slug) > maxSlugLength {
+		slug = slug[:maxSlugLength]
+		if i := strings.LastIndexByte(slug, '-'); i > maxSlugLength/2 {
+			slug = slug[:i]
+		}
+	}
+	return strings.Trim(slug, "-")
+}
+
+// foldWidth maps full-width ASCII to ASCII.
+func foldWidth(r rune) rune {
+	if r >= '！' && r <= '～' {
+		return r - ('！' - '!')
+	}
+	return r
+}
+
+// foldKana maps katakana to hiragana.
+func foldKana(r rune) rune {
+	if r >= 'ァ' && r <= 'ヴ' {
+		return r - ('ァ' - 'ぁ')
+	}
+	return r
+}
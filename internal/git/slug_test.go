@@ -0,0 +1,38 @@
+package git
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSlug(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"Fix login bug", "fix-login-bug"},
+		{"  Add --json flag!! ", "add-json-flag"},
+		{"v1.2.0 release", "v1-2-0-release"},
+		{"ＡＰＩ　エラー", "api-era"},
+		{"ログイン画面", "roguin"},
+		{"ログイン画面のバグを修正", "roguin-bagu"},
+		{"すてきなきのう", "sutekinakinou"},
+		{"ちょっと", "chotto"},
+		{"マッチ", "macchi"},
+		{"コンピューター", "konpyuta"},
+		{"フォーム", "fomu"},
+		{"ウェブ API", "webu-api"},
+		{"不具合修正", ""},
+		{"", ""},
+		{"!!!", ""},
+		{strings.Repeat("abc ", 20), "abc-abc-abc-abc-abc-abc-abc-abc-abc-abc"},
+		{strings.Repeat("a", 50), strings.Repeat("a", 40)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			if got := Slug(tt.in); got != tt.want {
+				t.Errorf("Slug(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package oauth
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+
+	"golang.org/x/oauth2"
+)
+
+// DefaultRedirectPort is the loopback port the login flow listens on.
+// The redirect URI registered for the Backlog application must match it.
+const DefaultRedirectPort = 8910
+
+const callbackPath = "/callback"
+
+// Endpoint returns the Backlog OAuth 2.0 endpoints of a space.
+func Endpoint(spaceURL string) oauth2.Endpoint {
+	return oauth2.Endpoint{
+		AuthURL:   spaceURL + "/OAuth2AccessRequest.action",
+		TokenURL:  spaceURL + "/api/v2/oauth2/token",
+		AuthStyle: oauth2.AuthStyleInParams,
+	}
+}
+
+// RedirectURL returns the loopback redirect URI for port.
+func RedirectURL(port int) string {
+	return "http://localhost:" + strconv.Itoa(port) + callbackPath
+}
+
+// Config returns the OAuth 2.0 client configuration for a space.
+func Config(spaceURL, clientID, clientSecret string, port int) *oauth2.Config {
+	return &oauth2.Config{
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		Endpoint:     Endpoint(spaceURL),
+		RedirectURL:  RedirectURL(port),
+	}
+}
+
+// Login runs the authorization code flow: it starts a loopback server,
+// hands the authorization URL to open and waits for Backlog to redirect
+// back with a code, which is then exchanged for a token.
+func Login(ctx context.Context, conf *oauth2.Config, port int, open func(string) error) (*oauth2.Token, error) {
+	state, err := randomState()
+	if err != nil {
+		return nil, err
+	}
+
+	ln, err := net.Listen("tcp", "localhost:"+strconv.Itoa(port))
+	if err != nil {
+		return nil, fmt.Errorf("ポート %d で待ち受けできません: %w", port, err)
+	}
+
+	type result struct {
+		code string
+		err  error
+	}
+	done := make(chan result, 1)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(callbackPath, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		var res result
+		switch {
+		case q.Get("error") != "":
+			res.err = fmt.Errorf("認可が拒否されました: %s", q.Get("error"))
+		case q.Get("state") != state:
+			res.err = errors.New("state が一致しません")
+		case q.Get("code") == "":
+			res.err = errors.New("認可コードが含まれていません")
+		default:
+			res.code = q.Get("code")
+		}
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		if res.err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintln(w, "認証に失敗しました: "+res.err.Error())
+		} else {
+			fmt.Fprintln(w, "認証が完了しました。このウィンドウを閉じてターミナルに戻ってください。")
+		}
+		select {
+		case done <- res:
+		default:
+		}
+	})
+
+	srv := &http.Server{Handler: mux}
+	go srv.Serve(ln)
+	defer srv.Close()
+
+	if err := open(conf.AuthCodeURL(state)); err != nil {
+		return nil, err
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case res := <-done:
+		if res.err != nil {
+			return nil, res.err
+		}
+		tok, err := conf.Exchange(ctx, res.code)
+		if err != nil {
+			return nil, fmt.Errorf("トークンの取得に失敗しました: %w", err)
+		}
+		return tok, nil
+	}
+}
+
+func randomState() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("state の生成に失敗しました: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
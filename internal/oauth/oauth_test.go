@@ -0,0 +1,151 @@
+package oauth
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newTokenServer returns a Backlog stand-in whose token endpoint accepts
+// the authorization code "good-code".
+func newTokenServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v2/oauth2/token" {
+			http.NotFound(w, r)
+			return
+		}
+		if err := r.ParseForm(); err != nil {
+			t.Errorf("ParseForm: %v", err)
+		}
+		if got := r.PostForm.Get("grant_type"); got != "authorization_code" {
+			t.Errorf("grant_type = %q, want authorization_code", got)
+		}
+		if got := r.PostForm.Get("client_id"); got != "client" {
+			t.Errorf("client_id = %q, want client", got)
+		}
+		if r.PostForm.Get("code") != "good-code" {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{
+			"access_token":  "access",
+			"refresh_token": "refresh",
+			"token_type":    "Bearer",
+			"expires_in":    3600,
+		})
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// freePort returns a loopback port that is free at the time of the call.
+func freePort(t *testing.T) int {
+	t.Helper()
+	ln, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+	return ln.Addr().(*net.TCPAddr).Port
+}
+
+func TestLogin(t *testing.T) {
+	tests := []struct {
+		name string
+		// callback returns the query Backlog redirects back with, given
+		// the state in the authorization URL.
+		callback   func(state string) url.Values
+		wantErr    string
+		wantAccess string
+	}{
+		{
+			name:       "code exchange",
+			callback:   func(state string) url.Values { return url.Values{"code": {"good-code"}, "state": {state}} },
+			wantAccess: "access",
+		},
+		{
+			name:     "state mismatch",
+			callback: func(string) url.Values { return url.Values{"code": {"good-code"}, "state": {"forged"}} },
+			wantErr:  "state が一致しません",
+		},
+		{
+			name:     "access denied",
+			callback: func(state string) url.Values { return url.Values{"error": {"access_denied"}, "state": {state}} },
+			wantErr:  "認可が拒否されました",
+		},
+		{
+			name:     "missing code",
+			callback: func(state string) url.Values { return url.Values{"state": {state}} },
+			wantErr:  "認可コードが含まれていません",
+		},
+		{
+			name:     "rejected code",
+			callback: func(state string) url.Values { return url.Values{"code": {"bad-code"}, "state": {state}} },
+			wantErr:  "トークンの取得に失敗しました",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := newTokenServer(t)
+			port := freePort(t)
+			conf := Config(srv.URL, "client", "secret", port)
+
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+
+			open := func(authURL string) error {
+				u, err := url.Parse(authURL)
+				if err != nil {
+					return err
+				}
+				if got := u.Query().Get("redirect_uri"); got != RedirectURL(port) {
+					t.Errorf("redirect_uri = %q, want %q", got, RedirectURL(port))
+				}
+				resp, err := http.Get(RedirectURL(port) + "?" + tt.callback(u.Query().Get("state")).Encode())
+				if err != nil {
+					return err
+				}
+				resp.Body.Close()
+				return nil
+			}
+
+			tok, err := Login(ctx, conf, port, open)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Login() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Login() error = %v", err)
+			}
+			if tok.AccessToken != tt.wantAccess || tok.RefreshToken != "refresh" {
+				t.Errorf("Login() = %+v, want access %q and refresh token", tok, tt.wantAccess)
+			}
+		})
+	}
+}
+
+func TestLoginCancelled(t *testing.T) {
+	srv := newTokenServer(t)
+	port := freePort(t)
+	conf := Config(srv.URL, "client", "secret", port)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	_, err := Login(ctx, conf, port, func(string) error {
+		cancel()
+		return nil
+	})
+	if err != context.Canceled {
+		t.Fatalf("Login() error = %v, want %v", err, context.Canceled)
+	}
+}
@@ -0,0 +1,259 @@
+package search
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/KimMaru10/bl-cli/internal/api"
+)
+
+// Resolve looks up the names in q within the project and adds the
+// conditions to opts. Options already set in opts, such as ProjectIDs,
+// are kept. closeStatus is the close_status configured for the project,
+// used by is:open and is:closed; if empty, the built-in 完了 is used.
+func (q *Query) Resolve(ctx context.Context, client *api.Client, projectKey, closeStatus string, opts *api.GetIssuesOptions) error {
+	statusIDs, err := q.resolveStatuses(ctx, client, projectKey, closeStatus)
+	if err != nil {
+		return err
+	}
+	if statusIDs != nil {
+		opts.StatusIDs = statusIDs
+	}
+	users := userResolver(ctx, client, projectKey)
+	if len(q.Assignees) > 0 {
+		if opts.AssigneeIDs, err = intersect("assignee", q.Assignees, users); err != nil {
+			return err
+		}
+	}
+	if len(q.Authors) > 0 {
+		if opts.CreatedUserIDs, err = intersect("author", q.Authors, users); err != nil {
+			return err
+		}
+	}
+	if len(q.IssueTypes) > 0 {
+		types, err := client.GetIssueTypes(ctx, projectKey)
+		if err != nil {
+			return err
+		}
+		if opts.IssueTypeIDs, err = intersect("type", q.IssueTypes, lookup("課題種別", types, func(t api.IssueType) (string, int) { return t.Name, t.ID })); err != nil {
+			return err
+		}
+	}
+	if len(q.Categories) > 0 {
+		categories, err := client.GetCategories(ctx, projectKey)
+		if err != nil {
+			return err
+		}
+		if opts.CategoryIDs, err = intersect("category", q.Categories, lookup("カテゴリー", categories, func(c api.Category) (string, int) { return c.Name, c.ID })); err != nil {
+			return err
+		}
+	}
+	if len(q.Priorities) > 0 {
+		priorities, err := client.GetPriorities(ctx)
+		if err != nil {
+			return err
+		}
+		if opts.PriorityIDs, err = intersect("priority", q.Priorities, lookup("優先度", priorities, func(p api.Priority) (string, int) { return p.Name, p.ID })); err != nil {
+			return err
+		}
+	}
+	if len(q.Milestones) > 0 {
+		milestones, err := client.GetMilestones(ctx, projectKey)
+		if err != nil {
+			return err
+		}
+		if opts.MilestoneIDs, err = intersect("milestone", q.Milestones, lookup("マイルストーン", milestones, func(m api.Milestone) (string, int) { return m.Name, m.ID })); err != nil {
+			return err
+		}
+	}
+	if len(q.Resolutions) > 0 {
+		resolutions, err := client.GetResolutions(ctx)
+		if err != nil {
+			return err
+		}
+		if opts.ResolutionIDs, err = intersect("resolution", q.Resolutions, lookup("完了理由", resolutions, func(r api.Resolution) (string, int) { return r.Name, r.ID })); err != nil {
+			return err
+		}
+	}
+
+	if q.Parent != "" {
+		parent, err := client.GetIssue(ctx, q.Parent)
+		if err != nil {
+			return err
+		}
+		opts.ParentIssueIDs = []int{parent.ID}
+	}
+	isParent, isChild := slices.Contains(q.Is, "parent"), slices.Contains(q.Is, "child")
+	switch {
+	case isParent && isChild:
+		return fmt.Errorf("is:parent と is:child は同時に指定できません")
+	case isParent:
+		opts.ParentChild = api.ParentChildParentOnly
+	case isChild:
+		opts.ParentChild = api.ParentChildChildOnly
+	}
+	if q.Attachment {
+		opts.Attachment = true
+	}
+
+	now := time.Now()
+	for _, d := range []struct {
+		key          string
+		exprs        []string
+		since, until *string
+	}{
+		{"created", q.Created, &opts.CreatedSince, &opts.CreatedUntil},
+		{"updated", q.Updated, &opts.UpdatedSince, &opts.UpdatedUntil},
+		{"start", q.Start, &opts.StartDateSince, &opts.StartDateUntil},
+		{"due", q.Due, &opts.DueDateSince, &opts.DueDateUntil},
+	} {
+		if len(d.exprs) == 0 {
+			continue
+		}
+		if *d.since, *d.until, err = combineDates(d.key, d.exprs, now); err != nil {
+			return err
+		}
+	}
+
+	if len(q.Keywords) > 0 {
+		opts.Keyword = strings.Join(q.Keywords, " ")
+	}
+	return nil
+}
+
+// resolveStatuses returns the IDs of the named statuses, narrowed by
+// is:open or is:closed. With only is:open, every status but the close
+// status is used.
+func (q *Query) resolveStatuses(ctx context.Context, client *api.Client, projectKey, closeStatus string) ([]int, error) {
+	open, closed := slices.Contains(q.Is, "open"), slices.Contains(q.Is, "closed")
+	if open && closed {
+		return nil, fmt.Errorf("is:open と is:closed は同時に指定できません")
+	}
+	if len(q.Statuses) == 0 && !open && !closed {
+		return nil, nil
+	}
+
+	statuses, err := client.GetStatuses(ctx, projectKey)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]int, 0, len(statuses))
+	if len(q.Statuses) > 0 {
+		if ids, err = intersect("status", q.Statuses, lookup("ステータス", statuses, func(s api.Status) (string, int) { return s.Name, s.ID })); err != nil {
+			return nil, err
+		}
+	} else {
+		for _, s := range statuses {
+			ids = append(ids, s.ID)
+		}
+	}
+
+	if open || closed {
+		ids = slices.DeleteFunc(ids, func(id int) bool {
+			i := slices.IndexFunc(statuses, func(s api.Status) bool { return s.ID == id })
+			return statuses[i].IsClosed(closeStatus) == open
+		})
+		if len(ids) == 0 {
+			return nil, fmt.Errorf("status: と is: の条件に一致するステータスがありません")
+		}
+	}
+	return ids, nil
+}
+
+// userResolver returns a function that looks up the IDs of the named
+// project members, with @me for the authenticated user. The members are
+// fetched once, on first use.
+func userResolver(ctx context.Context, client *api.Client, projectKey string) func([]string) ([]int, error) {
+	var members []api.User
+	return func(names []string) ([]int, error) {
+		var ids []int
+		for _, name := range names {
+			if name == "@me" {
+				me, err := client.GetMyself(ctx)
+				if err != nil {
+					return nil, err
+				}
+				ids = append(ids, me.ID)
+				continue
+			}
+			if members == nil {
+				var err error
+				if members, err = client.GetProjectUsers(ctx, projectKey); err != nil {
+					return nil, err
+				}
+			}
+			found, err := api.FindUsers(members, []string{name})
+			if err != nil {
+				return nil, err
+			}
+			ids = append(ids, api.UserIDs(found)...)
+		}
+		return ids, nil
+	}
+}
+
+// lookup returns a function that looks up the IDs of the items with the
+// given names.
+func lookup[T any](kind string, items []T, nameID func(T) (string, int)) func([]string) ([]int, error) {
+	return func(names []string) ([]int, error) {
+		var ids []int
+		for _, name := range names {
+			i := slices.IndexFunc(items, func(item T) bool {
+				n, _ := nameID(item)
+				return n == name
+			})
+			if i < 0 {
+				return nil, fmt.Errorf("%s「%s」が見つかりません", kind, name)
+			}
+			_, id := nameID(items[i])
+			ids = append(ids, id)
+		}
+		return ids, nil
+	}
+}
+
+// intersect resolves each term with ids and returns the IDs that match
+// every term, or nil if there are no terms. key names the query key in
+// the error reported when no ID is left.
+func intersect(key string, terms Terms, ids func([]string) ([]int, error)) ([]int, error) {
+	var result []int
+	for i, names := range terms {
+		got, err := ids(names)
+		if err != nil {
+			return nil, err
+		}
+		if i == 0 {
+			result = slices.Clone(got)
+			continue
+		}
+		result = slices.DeleteFunc(result, func(id int) bool { return !slices.Contains(got, id) })
+	}
+	if len(terms) > 0 && len(result) == 0 {
+		return nil, fmt.Errorf("%s: の条件をすべて満たす値がありません", key)
+	}
+	return result, nil
+}
+
+// combineDates intersects the ranges of several date expressions given
+// to key. It fails if no date is in every range.
+func combineDates(key string, exprs []string, now time.Time) (since, until string, err error) {
+	for _, expr := range exprs {
+		s, u, err := dateRange(expr, now)
+		if err != nil {
+			return "", "", fmt.Errorf("%s: %w", key, err)
+		}
+		if s != "" && s > since {
+			since = s
+		}
+		if u != "" && (until == "" || u < until) {
+			until = u
+		}
+	}
+	if since != "" && until != "" && since > until {
+		return "", "", fmt.Errorf("%s: の条件をすべて満たす日付がありません: %s", key, strings.Join(exprs, " "))
+	}
+	return since, until, nil
+}
@@ -0,0 +1,351 @@
+// Package search implements the compact issue filter language shared by
+// bl issue list and the MCP issue_list tool, e.g.
+//
+//	status:処理中 assignee:@me due:<2026-11-01 type:バグ
+//
+// A query is parsed into a Query holding names as written, and resolved
+// into api.GetIssuesOptions against a project.
+package search
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Syntax describes the query language for command help and tool
+// descriptions.
+const Syntax = `条件は「キー:値」を空白で区切って並べ、すべてを満たす課題を検索します。
+同じキーを繰り返した場合もすべてを満たす課題に絞り込みます。値をカンマで
+区切るといずれかに一致する課題を検索します。キーのない語はキーワード検索に
+なり、空白を含む値は "..." で囲みます。
+
+  status:処理中,処理済み   ステータス
+  assignee:@me             担当者（@me で自分）
+  author:yamada            登録者
+  type:バグ                課題種別
+  category:UI              カテゴリー
+  priority:高              優先度
+  milestone:v1.2.0         マイルストーン
+  resolution:対応済み      完了理由
+  parent:PROJ-1            指定した課題の子課題
+  created: updated: start: due:
+                           日付（2026-11-01, <2026-11-01, >=today, 2026-10-01..2026-10-31）
+  is:open / is:closed      未完了 / 完了
+  is:parent / is:child     子課題を持つ課題 / 子課題
+  has:attachment           添付ファイルあり`
+
+// dateLayout is the date format accepted in queries and by the API.
+const dateLayout = "2006-01-02"
+
+// Terms holds the values given to a list key such as status:. Each
+// element comes from one term and matches any of its comma-separated
+// values; an issue must match every term.
+type Terms [][]string
+
+// Query is a parsed issue filter. Names are kept as written and looked
+// up by Resolve.
+type Query struct {
+	Statuses    Terms
+	Assignees   Terms
+	Authors     Terms
+	IssueTypes  Terms
+	Categories  Terms
+	Priorities  Terms
+	Milestones  Terms
+	Resolutions Terms
+	Parent      string
+	// Is holds the is: qualifiers: open, closed, parent and child.
+	Is         []string
+	Attachment bool
+	// Date filters hold the expressions as written, e.g. "<2026-11-01".
+	// Several expressions for the same date are combined.
+	Created  []string
+	Updated  []string
+	Start    []string
+	Due      []string
+	Keywords []string
+}
+
+// Parse parses a query string. An empty string yields an empty query.
+func Parse(s string) (*Query, error) {
+	tokens, err := tokenize(s)
+	if err != nil {
+		return nil, err
+	}
+
+	q := &Query{}
+	for _, t := range tokens {
+		key, value, ok := strings.Cut(t.text, ":")
+		// URLs such as https://... are searched as keywords.
+		if t.literal || !ok || strings.HasPrefix(value, "//") || !isKey(key) {
+			q.Keywords = append(q.Keywords, t.text)
+			continue
+		}
+		if err := q.Set(key, value); err != nil {
+			return nil, err
+		}
+	}
+	return q, nil
+}
+
+// Set adds a condition given as key and value, as in "key:value". It is
+// also used to apply command-line flags to a query.
+func (q *Query) Set(key, value string) error {
+	key = strings.ToLower(key)
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return fmt.Errorf("%s: の値を指定してください", key)
+	}
+
+	lists := map[string]*Terms{
+		"status":     &q.Statuses,
+		"assignee":   &q.Assignees,
+		"author":     &q.Authors,
+		"type":       &q.IssueTypes,
+		"category":   &q.Categories,
+		"priority":   &q.Priorities,
+		"milestone":  &q.Milestones,
+		"resolution": &q.Resolutions,
+	}
+	if terms, ok := lists[key]; ok {
+		values := splitList(value)
+		if len(values) == 0 {
+			return fmt.Errorf("%s: の値を指定してください", key)
+		}
+		*terms = append(*terms, values)
+		return nil
+	}
+
+	switch key {
+	case "parent":
+		q.Parent = value
+	case "is":
+		for _, v := range splitList(value) {
+			v = strings.ToLower(v)
+			if !slices.Contains([]string{"open", "closed", "parent", "child"}, v) {
+				return fmt.Errorf("is:%s は指定できません（open, closed, parent, child のいずれか）", v)
+			}
+			q.Is = append(q.Is, v)
+		}
+	case "has":
+		if strings.ToLower(value) != "attachment" {
+			return fmt.Errorf("has:%s は指定できません（has:attachment のみ）", value)
+		}
+		q.Attachment = true
+	case "created", "updated", "start", "due":
+		if _, _, err := dateRange(value, time.Now()); err != nil {
+			return fmt.Errorf("%s: %w", key, err)
+		}
+		switch key {
+		case "created":
+			q.Created = append(q.Created, value)
+		case "updated":
+			q.Updated = append(q.Updated, value)
+		case "start":
+			q.Start = append(q.Start, value)
+		case "due":
+			q.Due = append(q.Due, value)
+		}
+	case "keyword":
+		q.Keywords = append(q.Keywords, value)
+	default:
+		return fmt.Errorf("不明な検索キーです: %s", key)
+	}
+	return nil
+}
+
+// isKey reports whether key looks like a query key. Any ASCII word is
+// treated as one so that a misspelled key is reported by Set instead of
+// being searched as a keyword, while words such as "対応:済" are not.
+func isKey(key string) bool {
+	if key == "" {
+		return false
+	}
+	for _, r := range key {
+		if r > unicode.MaxASCII || !(unicode.IsLetter(r) || r == '-' || r == '_') {
+			return false
+		}
+	}
+	return true
+}
+
+// splitList splits a comma-separated value, dropping empty items.
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+type token struct {
+	text string
+	// literal is set for tokens starting with a quote, which are always
+	// searched as keywords.
+	literal bool
+}
+
+// tokenize splits s on whitespace outside double quotes and removes the
+// quotes.
+func tokenize(s string) ([]token, error) {
+	var (
+		tokens  []token
+		cur     strings.Builder
+		quoted  bool
+		literal bool
+		started bool
+	)
+	flush := func() {
+		if started {
+			tokens = append(tokens, token{text: cur.String(), literal: literal})
+		}
+		cur.Reset()
+		literal, started = false, false
+	}
+
+	for _, r := range s {
+		switch {
+		case r == '"':
+			if !started {
+				literal = true
+			}
+			quoted = !quoted
+			started = true
+		case unicode.IsSpace(r) && !quoted:
+			flush()
+		default:
+			cur.WriteRune(r)
+			started = true
+		}
+	}
+	if quoted {
+		return nil, fmt.Errorf("引用符が閉じられていません: %s", s)
+	}
+	flush()
+	return tokens, nil
+}
+
+// dateRange converts a date expression into inclusive since and until
+// dates. Either bound may be empty.
+//
+//	2026-11-01               that day
+//	<2026-11-01 <=2026-11-01 before, or on or before
+//	>2026-11-01 >=2026-11-01 after, or on or after
+//	2026-10-01..2026-10-31   between, either side may be omitted
+//
+// "today" may be used in place of a date.
+func dateRange(expr string, now time.Time) (since, until string, err error) {
+	if from, to, ok := strings.Cut(expr, ".."); ok {
+		if from == "" && to == "" {
+			return "", "", fmt.Errorf("日付の範囲を指定してください: %s", expr)
+		}
+		if from != "" {
+			if since, err = parseDate(from, now, 0); err != nil {
+				return "", "", err
+			}
+		}
+		if to != "" {
+			if until, err = parseDate(to, now, 0); err != nil {
+				return "", "", err
+			}
+		}
+		if since != "" && until != "" && since > until {
+			return "", "", fmt.Errorf("日付の範囲が正しくありません: %s", expr)
+		}
+		return since, until, nil
+	}
+
+	switch {
+	case strings.HasPrefix(expr, "<="):
+		until, err = parseDate(expr[2:], now, 0)
+	case strings.HasPrefix(expr, "<"):
+		until, err = parseDate(expr[1:], now, -1)
+	case strings.HasPrefix(expr, ">="):
+		since, err = parseDate(expr[2:], now, 0)
+	case strings.HasPrefix(expr, ">"):
+		since, err = parseDate(expr[1:], now, 1)
+	default:
+		since, err = parseDate(expr, now, 0)
+		until = since
+	}
+	return since, until, err
+}
+
+// parseDate parses a date or "today" and shifts it by days.
+func parseDate(s string, now time.Time, days int) (string, error) {
+	var d time.Time
+	if strings.ToLower(s) == "today" {
+		d = now
+	} else {
+		var err error
+		if d, err = time.ParseInLocation(dateLayout, s, now.Location()); err != nil {
+			return "", fmt.Errorf("日付は yyyy-MM-dd 形式で指定してください: %s", s)
+		}
+	}
+	return d.AddDate(0, 0, days).Format(dateLayout), nil
+}
+
+// String formats q as a query string that Parse reads back to the same
+// query.
+func (q *Query) String() string {
+	var terms []string
+	add := func(key string, values ...string) {
+		if len(values) > 0 {
+			terms = append(terms, key+":"+quote(strings.Join(values, ",")))
+		}
+	}
+	for _, l := range []struct {
+		key   string
+		terms Terms
+	}{
+		{"status", q.Statuses},
+		{"assignee", q.Assignees},
+		{"author", q.Authors},
+		{"type", q.IssueTypes},
+		{"category", q.Categories},
+		{"priority", q.Priorities},
+		{"milestone", q.Milestones},
+		{"resolution", q.Resolutions},
+	} {
+		for _, values := range l.terms {
+			add(l.key, values...)
+		}
+	}
+	if q.Parent != "" {
+		add("parent", q.Parent)
+	}
+	add("is", q.Is...)
+	if q.Attachment {
+		add("has", "attachment")
+	}
+	for _, d := range []struct {
+		key   string
+		exprs []string
+	}{{"created", q.Created}, {"updated", q.Updated}, {"start", q.Start}, {"due", q.Due}} {
+		for _, expr := range d.exprs {
+			add(d.key, expr)
+		}
+	}
+	for _, k := range q.Keywords {
+		if strings.ContainsAny(k, " \t:\"") {
+			// A leading quote keeps the word a keyword.
+			terms = append(terms, `"`+strings.ReplaceAll(k, `"`, "")+`"`)
+			continue
+		}
+		terms = append(terms, k)
+	}
+	return strings.Join(terms, " ")
+}
+
+// quote wraps value in double quotes if it contains whitespace.
+func quote(value string) string {
+	if strings.IndexFunc(value, unicode.IsSpace) < 0 {
+		return value
+	}
+	return `"` + value + `"`
+}
@@ -0,0 +1,251 @@
+package search
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		want    *Query
+		wantErr string
+	}{
+		{"empty", "", &Query{}, ""},
+		{
+			"keys",
+			"status:処理中 assignee:@me type:バグ",
+			&Query{Statuses: Terms{{"処理中"}}, Assignees: Terms{{"@me"}}, IssueTypes: Terms{{"バグ"}}},
+			"",
+		},
+		{
+			"comma is OR, repeated key is AND",
+			"category:UI,API category:API",
+			&Query{Categories: Terms{{"UI", "API"}, {"API"}}},
+			"",
+		},
+		{"key is case-insensitive", "Status:処理中", &Query{Statuses: Terms{{"処理中"}}}, ""},
+		{"quoted value", `type:"機能 追加"`, &Query{IssueTypes: Terms{{"機能 追加"}}}, ""},
+		{"keywords", "ログイン エラー", &Query{Keywords: []string{"ログイン", "エラー"}}, ""},
+		{"quoted keyword with colon", `"TODO:fix"`, &Query{Keywords: []string{"TODO:fix"}}, ""},
+		{"URL is a keyword", "https://example.com/a", &Query{Keywords: []string{"https://example.com/a"}}, ""},
+		{"non-ASCII key is a keyword", "対応:済", &Query{Keywords: []string{"対応:済"}}, ""},
+		{
+			"qualifiers",
+			"is:Open,parent has:attachment parent:PROJ-1",
+			&Query{Is: []string{"open", "parent"}, Attachment: true, Parent: "PROJ-1"},
+			"",
+		},
+		{
+			"dates",
+			"due:<2026-11-01 due:>=2026-10-01 created:today",
+			&Query{Due: []string{"<2026-11-01", ">=2026-10-01"}, Created: []string{"today"}},
+			"",
+		},
+		{"unknown key", "TODO:fix", nil, "不明な検索キーです: todo"},
+		{"empty value", "status:", nil, "status: の値を指定してください"},
+		{"empty list", "status:,", nil, "status: の値を指定してください"},
+		{"bad is", "is:draft", nil, "is:draft は指定できません"},
+		{"bad has", "has:comment", nil, "has:comment は指定できません"},
+		{"bad date", "due:2026-13-01", nil, "due: 日付は yyyy-MM-dd 形式で指定してください"},
+		{"empty range", "due:..", nil, "due: 日付の範囲を指定してください"},
+		{"reversed range", "due:2026-11-01..2026-10-01", nil, "due: 日付の範囲が正しくありません"},
+		{"unclosed quote", `"ログイン`, nil, "引用符が閉じられていません"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.in)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Parse(%q) error = %v, want %q", tt.in, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Parse(%q) error = %v", tt.in, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Parse(%q) = %+v, want %+v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQueryStringRoundTrip(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"", ""},
+		{"status:処理中,処理済み status:処理中", "status:処理中,処理済み status:処理中"},
+		{`type:"機能 追加" ログイン`, `type:"機能 追加" ログイン`},
+		{"ログイン assignee:@me", "assignee:@me ログイン"},
+		{`"TODO:fix" https://example.com/a`, `"TODO:fix" "https://example.com/a"`},
+		{`"ログイン エラー"`, `"ログイン エラー"`},
+		{"is:open has:attachment parent:PROJ-1", "parent:PROJ-1 is:open has:attachment"},
+		{"due:<2026-11-01 due:>=today updated:2026-10-01..", "updated:2026-10-01.. due:<2026-11-01 due:>=today"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			q, err := Parse(tt.in)
+			if err != nil {
+				t.Fatalf("Parse(%q) error = %v", tt.in, err)
+			}
+			s := q.String()
+			if s != tt.want {
+				t.Errorf("String() = %q, want %q", s, tt.want)
+			}
+			again, err := Parse(s)
+			if err != nil {
+				t.Fatalf("Parse(%q) error = %v", s, err)
+			}
+			if !reflect.DeepEqual(again, q) {
+				t.Errorf("Parse(String()) = %+v, want %+v", again, q)
+			}
+		})
+	}
+}
+
+func TestDateRange(t *testing.T) {
+	now := time.Date(2026, 10, 18, 15, 0, 0, 0, time.UTC)
+	tests := []struct {
+		expr      string
+		since     string
+		until     string
+		wantError bool
+	}{
+		{"2026-11-01", "2026-11-01", "2026-11-01", false},
+		{"today", "2026-10-18", "2026-10-18", false},
+		{"TODAY", "2026-10-18", "2026-10-18", false},
+		{"<2026-11-01", "", "2026-10-31", false},
+		{"<=2026-11-01", "", "2026-11-01", false},
+		{">2026-10-31", "2026-11-01", "", false},
+		{">=today", "2026-10-18", "", false},
+		{"<2026-03-01", "", "2026-02-28", false},
+		{"2026-10-01..2026-10-31", "2026-10-01", "2026-10-31", false},
+		{"2026-10-01..", "2026-10-01", "", false},
+		{"..today", "", "2026-10-18", false},
+		{"2026-10-01..2026-10-01", "2026-10-01", "2026-10-01", false},
+		{"..", "", "", true},
+		{"2026-10-31..2026-10-01", "", "", true},
+		{"2026/10/01", "", "", true},
+		{"<", "", "", true},
+		{"yesterday", "", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			since, until, err := dateRange(tt.expr, now)
+			if tt.wantError {
+				if err == nil {
+					t.Fatalf("dateRange(%q) = %q, %q, want error", tt.expr, since, until)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("dateRange(%q) error = %v", tt.expr, err)
+			}
+			if since != tt.since || until != tt.until {
+				t.Errorf("dateRange(%q) = %q, %q, want %q, %q", tt.expr, since, until, tt.since, tt.until)
+			}
+		})
+	}
+}
+
+func TestCombineDates(t *testing.T) {
+	now := time.Date(2026, 10, 18, 15, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		exprs   []string
+		since   string
+		until   string
+		wantErr string
+	}{
+		{"single", []string{"<2026-11-01"}, "", "2026-10-31", ""},
+		{"open bounds", []string{">=2026-10-01", "<=2026-10-31"}, "2026-10-01", "2026-10-31", ""},
+		{"narrowed", []string{"2026-10-01..2026-10-31", ">=2026-10-15", "<2026-10-20"}, "2026-10-15", "2026-10-19", ""},
+		{"single day", []string{">=2026-10-15", "<=2026-10-15"}, "2026-10-15", "2026-10-15", ""},
+		{"disjoint", []string{"<2026-01-01", ">2026-02-01"}, "", "", "due: の条件をすべて満たす日付がありません"},
+		{"adjacent", []string{"<2026-10-15", ">=2026-10-15"}, "", "", "due: の条件をすべて満たす日付がありません"},
+		{"invalid", []string{"<2026-01-01", "soon"}, "", "", "due: 日付は yyyy-MM-dd 形式で指定してください"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			since, until, err := combineDates("due", tt.exprs, now)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("combineDates(%q) error = %v, want %q", tt.exprs, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("combineDates(%q) error = %v", tt.exprs, err)
+			}
+			if since != tt.since || until != tt.until {
+				t.Errorf("combineDates(%q) = %q, %q, want %q, %q", tt.exprs, since, until, tt.since, tt.until)
+			}
+		})
+	}
+}
+
+func TestIntersect(t *testing.T) {
+	statuses := []string{"", "未対応", "処理中", "処理済み", "完了"}
+	ids := func(names []string) ([]int, error) {
+		var out []int
+		for _, n := range names {
+			out = append(out, slices.Index(statuses, n))
+		}
+		return out, nil
+	}
+
+	tests := []struct {
+		name    string
+		terms   Terms
+		want    []int
+		wantErr bool
+	}{
+		{"no terms", nil, nil, false},
+		{"one term", Terms{{"未対応", "処理中"}}, []int{1, 2}, false},
+		{"overlap", Terms{{"未対応", "処理中"}, {"処理中", "完了"}}, []int{2}, false},
+		{"disjoint", Terms{{"未対応"}, {"完了"}}, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := intersect("status", tt.terms, ids)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("intersect() = %v, want error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("intersect() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("intersect() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLookup(t *testing.T) {
+	type item struct {
+		id   int
+		name string
+	}
+	ids := lookup("優先度", []item{{2, "高"}, {3, "中"}, {4, "低"}}, func(i item) (string, int) { return i.name, i.id })
+
+	got, err := ids([]string{"低", "高"})
+	if err != nil {
+		t.Fatalf("lookup() error = %v", err)
+	}
+	if want := []int{4, 2}; !reflect.DeepEqual(got, want) {
+		t.Errorf("lookup() = %v, want %v", got, want)
+	}
+	if _, err := ids([]string{"最高"}); err == nil || !strings.Contains(err.Error(), "優先度「最高」が見つかりません") {
+		t.Errorf("lookup(最高) error = %v, want not found", err)
+	}
+}
@@ -0,0 +1,152 @@
+// Package timer keeps a local stopwatch of time spent on issues until it
+// is committed to Backlog as actual hours.
+package timer
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/KimMaru10/bl-cli/internal/config"
+)
+
+const storeFile = "timers.json"
+
+// ErrNotRunning is returned by Stop when no timer is running.
+var ErrNotRunning = errors.New("計測中のタイマーはありません")
+
+// Session is a period of work on an issue. End is zero while the timer
+// is running.
+type Session struct {
+	Space     string    `json:"space"`
+	IssueKey  string    `json:"issueKey"`
+	Start     time.Time `json:"start"`
+	End       time.Time `json:"end,omitzero"`
+	Committed bool      `json:"committed,omitempty"`
+}
+
+// Duration returns the length of the session, measured up to now while
+// it is running.
+func (s Session) Duration(now time.Time) time.Duration {
+	if s.End.IsZero() {
+		return now.Sub(s.Start)
+	}
+	return s.End.Sub(s.Start)
+}
+
+// Store holds the running timer and the completed sessions. It is saved
+// as JSON in the config directory.
+type Store struct {
+	Running  *Session  `json:"running,omitempty"`
+	Sessions []Session `json:"sessions"`
+
+	path string
+}
+
+// Load reads the store, returning an empty one if it does not exist yet.
+func Load() (*Store, error) {
+	dir, err := config.Dir()
+	if err != nil {
+		return nil, err
+	}
+	s := &Store{path: filepath.Join(dir, storeFile)}
+
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return s, nil
+		}
+		return nil, fmt.Errorf("タイマーの読み込みに失敗しました: %w", err)
+	}
+	if err := json.Unmarshal(data, s); err != nil {
+		return nil, fmt.Errorf("%s の解析に失敗しました: %w", s.path, err)
+	}
+	return s, nil
+}
+
+// Save writes the store back to disk. The file is replaced atomically so
+// that an interrupted write cannot lose recorded sessions.
+func (s *Store) Save() error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("タイマーのシリアライズに失敗しました: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return fmt.Errorf("設定ディレクトリの作成に失敗しました: %w", err)
+	}
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return fmt.Errorf("タイマーの書き込みに失敗しました: %w", err)
+	}
+	if err := os.Rename(tmp, s.path); err != nil {
+		return fmt.Errorf("タイマーの書き込みに失敗しました: %w", err)
+	}
+	return nil
+}
+
+// Start starts a timer for issueKey. Only one timer runs at a time, so a
+// timer already running for another issue is stopped and returned.
+func (s *Store) Start(space, issueKey string, now time.Time) (*Session, error) {
+	var stopped *Session
+	if s.Running != nil {
+		if s.Running.Space == space && s.Running.IssueKey == issueKey {
+			return nil, fmt.Errorf("%s のタイマーは既に計測中です", issueKey)
+		}
+		var err error
+		if stopped, err = s.Stop(now); err != nil {
+			return nil, err
+		}
+	}
+	s.Running = &Session{Space: space, IssueKey: issueKey, Start: now}
+	return stopped, nil
+}
+
+// Stop stops the running timer and records it as a completed session.
+func (s *Store) Stop(now time.Time) (*Session, error) {
+	if s.Running == nil {
+		return nil, ErrNotRunning
+	}
+	session := *s.Running
+	session.End = now
+	s.Sessions = append(s.Sessions, session)
+	s.Running = nil
+	return &session, nil
+}
+
+// Pending returns the completed sessions in space that have not been
+// committed, limited to issueKey unless it is empty.
+func (s *Store) Pending(space, issueKey string) []Session {
+	var pending []Session
+	for _, session := range s.Sessions {
+		if session.Committed || session.Space != space {
+			continue
+		}
+		if issueKey != "" && session.IssueKey != issueKey {
+			continue
+		}
+		pending = append(pending, session)
+	}
+	return pending
+}
+
+// MarkCommitted marks the pending sessions of issueKey in space as
+// committed.
+func (s *Store) MarkCommitted(space, issueKey string) {
+	for i, session := range s.Sessions {
+		if !session.Committed && session.Space == space && session.IssueKey == issueKey {
+			s.Sessions[i].Committed = true
+		}
+	}
+}
+
+// Total returns the summed duration of completed sessions.
+func Total(sessions []Session) time.Duration {
+	var total time.Duration
+	for _, session := range sessions {
+		total += session.End.Sub(session.Start)
+	}
+	return total
+}
@@ -0,0 +1,150 @@
+package timer
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/KimMaru10/bl-cli/internal/config"
+)
+
+var t0 = time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC)
+
+func at(minutes int) time.Time {
+	return t0.Add(time.Duration(minutes) * time.Minute)
+}
+
+func TestStoreStartStop(t *testing.T) {
+	s := &Store{}
+
+	if _, err := s.Stop(at(0)); !errors.Is(err, ErrNotRunning) {
+		t.Fatalf("Stop() without a timer error = %v, want ErrNotRunning", err)
+	}
+
+	if stopped, err := s.Start("main", "PROJ-1", at(0)); err != nil || stopped != nil {
+		t.Fatalf("Start(PROJ-1) = %v, %v, want nil, nil", stopped, err)
+	}
+	if _, err := s.Start("main", "PROJ-1", at(5)); err == nil {
+		t.Fatal("Start(PROJ-1) twice succeeded, want error")
+	}
+	if got := s.Running.Duration(at(10)); got != 10*time.Minute {
+		t.Errorf("running Duration() = %v, want 10m", got)
+	}
+
+	// Starting another issue stops the running timer.
+	stopped, err := s.Start("main", "PROJ-2", at(30))
+	if err != nil {
+		t.Fatalf("Start(PROJ-2) error = %v", err)
+	}
+	if stopped == nil || stopped.IssueKey != "PROJ-1" || !stopped.End.Equal(at(30)) {
+		t.Fatalf("Start(PROJ-2) stopped %+v, want PROJ-1 ending at %v", stopped, at(30))
+	}
+	// The same key in another space is a different issue.
+	if stopped, err = s.Start("other", "PROJ-2", at(45)); err != nil || stopped.IssueKey != "PROJ-2" {
+		t.Fatalf("Start(other PROJ-2) = %+v, %v, want PROJ-2 stopped", stopped, err)
+	}
+
+	session, err := s.Stop(at(60))
+	if err != nil {
+		t.Fatalf("Stop() error = %v", err)
+	}
+	if session.Space != "other" || session.Duration(at(999)) != 15*time.Minute {
+		t.Errorf("Stop() = %+v, want a 15m session in other", session)
+	}
+	if s.Running != nil {
+		t.Errorf("Running = %+v after Stop, want nil", s.Running)
+	}
+	if len(s.Sessions) != 3 {
+		t.Errorf("len(Sessions) = %d, want 3", len(s.Sessions))
+	}
+}
+
+func TestStorePending(t *testing.T) {
+	s := &Store{Sessions: []Session{
+		{Space: "main", IssueKey: "PROJ-1", Start: at(0), End: at(30)},
+		{Space: "main", IssueKey: "PROJ-2", Start: at(30), End: at(40)},
+		{Space: "main", IssueKey: "PROJ-1", Start: at(40), End: at(70)},
+		{Space: "main", IssueKey: "PROJ-1", Start: at(70), End: at(80), Committed: true},
+		{Space: "other", IssueKey: "PROJ-1", Start: at(80), End: at(90)},
+	}}
+
+	tests := []struct {
+		space, issueKey string
+		wantCount       int
+		wantTotal       time.Duration
+	}{
+		{"main", "", 3, 70 * time.Minute},
+		{"main", "PROJ-1", 2, time.Hour},
+		{"main", "PROJ-3", 0, 0},
+		{"other", "PROJ-1", 1, 10 * time.Minute},
+		{"none", "", 0, 0},
+	}
+	for _, tt := range tests {
+		pending := s.Pending(tt.space, tt.issueKey)
+		if len(pending) != tt.wantCount || Total(pending) != tt.wantTotal {
+			t.Errorf("Pending(%q, %q) = %d sessions, %v, want %d, %v",
+				tt.space, tt.issueKey, len(pending), Total(pending), tt.wantCount, tt.wantTotal)
+		}
+	}
+
+	s.MarkCommitted("main", "PROJ-1")
+	if got := len(s.Pending("main", "PROJ-1")); got != 0 {
+		t.Errorf("Pending(main, PROJ-1) after MarkCommitted = %d sessions, want 0", got)
+	}
+	if got := len(s.Pending("main", "PROJ-2")); got != 1 {
+		t.Errorf("Pending(main, PROJ-2) after MarkCommitted = %d sessions, want 1", got)
+	}
+	if got := len(s.Pending("other", "PROJ-1")); got != 1 {
+		t.Errorf("Pending(other, PROJ-1) after MarkCommitted = %d sessions, want 1", got)
+	}
+}
+
+func TestStoreSaveLoad(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv(config.EnvConfigDir, dir)
+
+	s, err := Load()
+	if err != nil {
+		t.Fatalf("Load() of a missing store error = %v", err)
+	}
+	if s.Running != nil || len(s.Sessions) != 0 {
+		t.Fatalf("Load() of a missing store = %+v, want empty", s)
+	}
+
+	if _, err := s.Start("main", "PROJ-1", at(0)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Start("main", "PROJ-2", at(30)); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Save(); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, storeFile+".tmp")); !os.IsNotExist(err) {
+		t.Errorf("temporary file left behind: %v", err)
+	}
+
+	loaded, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if loaded.Running == nil || loaded.Running.IssueKey != "PROJ-2" || !loaded.Running.Start.Equal(at(30)) || !loaded.Running.End.IsZero() {
+		t.Errorf("loaded Running = %+v, want PROJ-2 started at %v", loaded.Running, at(30))
+	}
+	if len(loaded.Sessions) != 1 || loaded.Sessions[0].IssueKey != "PROJ-1" || Total(loaded.Sessions) != 30*time.Minute {
+		t.Errorf("loaded Sessions = %+v, want one 30m PROJ-1 session", loaded.Sessions)
+	}
+}
+
+func TestLoadInvalid(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv(config.EnvConfigDir, dir)
+	if err := os.WriteFile(filepath.Join(dir, storeFile), []byte("{"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(); err == nil {
+		t.Error("Load() of an invalid file succeeded, want error")
+	}
+}
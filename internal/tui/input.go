@@ -40,6 +40,18 @@ func Input(prompt, placeholder string) (string, bool) {
 	ti := textinput.New()
 	ti.Prompt = prompt
 	ti.Placeholder = placeholder
+	return runInput(ti)
+}
+
+// Password is like Input but masks the entered characters.
+func Password(prompt string) (string, bool) {
+	ti := textinput.New()
+	ti.Prompt = prompt
+	ti.EchoMode = textinput.EchoPassword
+	return runInput(ti)
+}
+
+func runInput(ti textinput.Model) (string, bool) {
 	ti.Focus()
 	ti.Width = 50
 
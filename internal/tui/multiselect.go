@@ -0,0 +1,117 @@
+package tui
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type multiSelectDelegate struct {
+	checked map[int]bool
+}
+
+func (d multiSelectDelegate) Height() int                             { return 1 }
+func (d multiSelectDelegate) Spacing() int                            { return 0 }
+func (d multiSelectDelegate) Update(_ tea.Msg, _ *list.Model) tea.Cmd { return nil }
+
+func (d multiSelectDelegate) Render(w io.Writer, m list.Model, index int, listItem list.Item) {
+	i, ok := listItem.(SelectItem)
+	if !ok {
+		return
+	}
+	box := "[ ] "
+	if d.checked[i.ID] {
+		box = "[x] "
+	}
+	if index == m.Index() {
+		fmt.Fprint(w, lipgloss.NewStyle().Foreground(lipgloss.Color("6")).Render("> "+box+i.Label))
+	} else {
+		fmt.Fprint(w, "  "+box+i.Label)
+	}
+}
+
+type multiSelectModel struct {
+	list     list.Model
+	checked  map[int]bool
+	done     bool
+	quitting bool
+}
+
+func (m multiSelectModel) Init() tea.Cmd { return nil }
+
+func (m multiSelectModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok && m.list.FilterState() != list.Filtering {
+		switch msg.Type {
+		case tea.KeyCtrlC, tea.KeyEsc:
+			m.quitting = true
+			return m, tea.Quit
+		case tea.KeyEnter:
+			m.done = true
+			return m, tea.Quit
+		case tea.KeySpace:
+			if item, ok := m.list.SelectedItem().(SelectItem); ok {
+				m.checked[item.ID] = !m.checked[item.ID]
+			}
+			return m, nil
+		}
+	}
+	var cmd tea.Cmd
+	m.list, cmd = m.list.Update(msg)
+	return m, cmd
+}
+
+func (m multiSelectModel) View() string {
+	if m.done {
+		return ""
+	}
+	return m.list.View()
+}
+
+// MultiSelect shows an interactive list where items are toggled with
+// space and confirmed with enter. Items whose ID is in checkedIDs start
+// checked. It returns the checked items in list order, and false if the
+// user cancelled.
+func MultiSelect(title string, items []SelectItem, checkedIDs []int) ([]SelectItem, bool) {
+	listItems := make([]list.Item, len(items))
+	for i, item := range items {
+		listItems[i] = item
+	}
+
+	checked := map[int]bool{}
+	for _, id := range checkedIDs {
+		checked[id] = true
+	}
+	l := list.New(listItems, multiSelectDelegate{checked: checked}, 50, min(len(items)+6, 20))
+	l.Title = title
+	l.SetShowStatusBar(false)
+	l.SetShowHelp(true)
+	l.AdditionalShortHelpKeys = func() []key.Binding {
+		return []key.Binding{
+			key.NewBinding(key.WithKeys(" "), key.WithHelp("space", "選択")),
+			key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "決定")),
+		}
+	}
+
+	m := multiSelectModel{list: l, checked: checked}
+	p := tea.NewProgram(m)
+	finalModel, err := p.Run()
+	if err != nil {
+		return nil, false
+	}
+
+	result := finalModel.(multiSelectModel)
+	if result.quitting {
+		return nil, false
+	}
+	var selected []SelectItem
+	for _, item := range items {
+		if result.checked[item.ID] {
+			selected = append(selected, item)
+		}
+	}
+	return selected, true
+}
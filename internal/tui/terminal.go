@@ -0,0 +1,17 @@
+package tui
+
+import (
+	"os"
+
+	"github.com/mattn/go-isatty"
+)
+
+// Interactive reports whether stdin and stdout are both terminals, so
+// that a prompt can be shown and answered.
+func Interactive() bool {
+	return isTerminal(os.Stdin) && isTerminal(os.Stdout)
+}
+
+func isTerminal(f *os.File) bool {
+	return isatty.IsTerminal(f.Fd()) || isatty.IsCygwinTerminal(f.Fd())
+}
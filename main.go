@@ -4,6 +4,7 @@ import (
 	"os"
 
 	"github.com/KimMaru10/bl-cli/cmd"
+	"github.com/KimMaru10/bl-cli/internal/cmdutil"
 )
 
 var version = "dev"
@@ -11,6 +12,6 @@ var version = "dev"
 func main() {
 	cmd.SetVersion(version)
 	if err := cmd.Execute(); err != nil {
-		os.Exit(1)
+		os.Exit(cmdutil.ExitCode(err))
 	}
 }